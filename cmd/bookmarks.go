@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+var (
+	bookmarksJSON   bool
+	bookmarksFormat string
+)
+
+var bookmarksCmd = &cobra.Command{
+	Use:   "bookmarks",
+	Short: "List, add, remove and export bookmarked headlines",
+	Long: `Manage headlines starred with Ctrl+S in 'economist browse'.
+
+Bookmarks keep the headline, standfirst and date, so they stay listed after
+the article drops out of the RSS feed. Reference bookmarks by their number in
+'bookmarks list' or by URL.
+
+Examples:
+  economist bookmarks
+  economist bookmarks add https://www.economist.com/...
+  economist bookmarks rm 2
+  economist bookmarks export --format html > bookmarks.html`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksList,
+}
+
+var bookmarksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bookmarks",
+	Args:  cobra.NoArgs,
+	RunE:  runBookmarksList,
+}
+
+var bookmarksAddCmd = &cobra.Command{
+	Use:   "add [url|-]",
+	Short: "Bookmark an article",
+	Args:  cobra.RangeArgs(0, 1),
+	RunE:  runBookmarksAdd,
+}
+
+var bookmarksRmCmd = &cobra.Command{
+	Use:   "rm <number|url>",
+	Short: "Remove a bookmark",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBookmarksRm,
+}
+
+var bookmarksExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export bookmarks as Markdown, HTML or JSON",
+	Long: `Write bookmarks to stdout.
+
+Formats:
+  md    Markdown list (default)
+  html  Netscape bookmark file, importable by web browsers
+  json  same as 'bookmarks list --json'`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksExport,
+}
+
+func init() {
+	bookmarksCmd.Flags().BoolVar(&bookmarksJSON, "json", false, "Output JSON")
+	bookmarksListCmd.Flags().BoolVar(&bookmarksJSON, "json", false, "Output JSON")
+	bookmarksExportCmd.Flags().StringVar(&bookmarksFormat, "format", "md", "Export format: md, html or json")
+
+	bookmarksCmd.AddCommand(bookmarksListCmd)
+	bookmarksCmd.AddCommand(bookmarksAddCmd)
+	bookmarksCmd.AddCommand(bookmarksRmCmd)
+	bookmarksCmd.AddCommand(bookmarksExportCmd)
+	rootCmd.AddCommand(bookmarksCmd)
+}
+
+type bookmarkOutput struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Date        string   `json:"date,omitempty"`
+	PubDate     string   `json:"pub_date,omitempty"`
+	URL         string   `json:"url"`
+	Sections    []string `json:"sections,omitempty"`
+	AddedAt     string   `json:"added_at"`
+}
+
+func runBookmarksList(cmd *cobra.Command, args []string) error {
+	list, err := bookmarks.List()
+	if err != nil {
+		return err
+	}
+
+	if bookmarksJSON {
+		return printBookmarksJSON(list)
+	}
+
+	if len(list) == 0 {
+		fmt.Println("No bookmarks. Star headlines with Ctrl+S in 'economist browse'.")
+		return nil
+	}
+
+	numWidth := len(strconv.Itoa(len(list)))
+	pad := strings.Repeat(" ", numWidth+2)
+	for i, b := range list {
+		fmt.Printf("%*d. %s\n", numWidth, i+1, b.Title)
+		meta := b.URL
+		if date := b.Item().FormattedDate(); date != "" {
+			meta = date + " · " + meta
+		}
+		if len(b.Sections) > 0 {
+			meta = strings.Join(b.Sections, ", ") + " · " + meta
+		}
+		fmt.Printf("%s%s\n", pad, meta)
+	}
+	return nil
+}
+
+func runBookmarksAdd(cmd *cobra.Command, args []string) error {
+	url, err := resolveURL(args)
+	if err != nil {
+		return err
+	}
+
+	b, err := lookupBookmark(url)
+	if err != nil {
+		return err
+	}
+	added, err := bookmarks.Add(b)
+	if err != nil {
+		return err
+	}
+	if !added {
+		fmt.Printf("Already bookmarked: %s\n", b.Title)
+		return nil
+	}
+	fmt.Printf("Bookmarked: %s\n", b.Title)
+	return nil
+}
+
+// lookupBookmark finds headline metadata for url, preferring the RSS feeds
+// and falling back to fetching the article itself.
+func lookupBookmark(url string) (bookmarks.Bookmark, error) {
+	items, _ := rss.FetchSections(rss.AllSections())
+	for _, item := range items {
+		if item.Link == url {
+			return bookmarks.New(item.Item, item.Sections), nil
+		}
+	}
+
+	art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+	if err != nil {
+		return bookmarks.Bookmark{}, err
+	}
+	item := rss.Item{Title: art.Title, Description: art.Subtitle, Link: url}
+	return bookmarks.New(item, nil), nil
+}
+
+func runBookmarksRm(cmd *cobra.Command, args []string) error {
+	list, err := bookmarks.List()
+	if err != nil {
+		return err
+	}
+
+	target := rss.Item{Link: args[0]}
+	title := args[0]
+	if idx, err := strconv.Atoi(args[0]); err == nil {
+		if idx < 1 || idx > len(list) {
+			return appErrors.NewUserError("no bookmark #%d - see 'economist bookmarks'", idx)
+		}
+		target = list[idx-1].Item()
+		title = list[idx-1].Title
+	}
+
+	removed, err := bookmarks.Remove(target)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return appErrors.NewUserError("not bookmarked: %s", args[0])
+	}
+	fmt.Printf("Removed: %s\n", title)
+	return nil
+}
+
+func runBookmarksExport(cmd *cobra.Command, args []string) error {
+	list, err := bookmarks.List()
+	if err != nil {
+		return err
+	}
+
+	switch bookmarksFormat {
+	case "json":
+		return printBookmarksJSON(list)
+	case "md", "markdown":
+		fmt.Print(bookmarksMarkdown(list))
+	case "html":
+		fmt.Print(bookmarksHTML(list))
+	default:
+		return appErrors.NewUserError("unknown format %q - use md, html or json", bookmarksFormat)
+	}
+	return nil
+}
+
+func printBookmarksJSON(list []bookmarks.Bookmark) error {
+	out := make([]bookmarkOutput, 0, len(list))
+	for _, b := range list {
+		out = append(out, bookmarkOutput{
+			Title:       b.Title,
+			Description: b.Description,
+			Date:        b.Item().FormattedDate(),
+			PubDate:     b.PubDate,
+			URL:         b.URL,
+			Sections:    b.Sections,
+			AddedAt:     b.AddedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return writeJSON(os.Stdout, out)
+}
+
+func bookmarksMarkdown(list []bookmarks.Bookmark) string {
+	var b strings.Builder
+	b.WriteString("# Bookmarks\n\n")
+	for _, bm := range list {
+		b.WriteString("- " + article.MarkdownLink(bm.Title, bm.URL))
+		if date := bm.Item().FormattedDate(); date != "" {
+			fmt.Fprintf(&b, " — %s", date)
+		}
+		b.WriteString("\n")
+		if bm.Description != "" {
+			fmt.Fprintf(&b, "  %s\n", bm.Description)
+		}
+	}
+	return b.String()
+}
+
+// bookmarksHTML renders the Netscape bookmark file format that browsers import.
+func bookmarksHTML(list []bookmarks.Bookmark) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString("<META HTTP-EQUIV=\"Content-Type\" CONTENT=\"text/html; charset=UTF-8\">\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n<H1>Bookmarks</H1>\n<DL><p>\n")
+	b.WriteString("    <DT><H3>The Economist</H3>\n    <DL><p>\n")
+	for _, bm := range list {
+		fmt.Fprintf(&b, "        <DT><A HREF=\"%s\" ADD_DATE=\"%d\">%s</A>\n",
+			html.EscapeString(bm.URL), bm.AddedAt.Unix(), html.EscapeString(bm.Title))
+		if bm.Description != "" {
+			fmt.Fprintf(&b, "        <DD>%s\n", html.EscapeString(bm.Description))
+		}
+	}
+	b.WriteString("    </DL><p>\n</DL><p>\n")
+	return b.String()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/tmustier/economist-tui/internal/daemon"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
@@ -19,15 +20,20 @@ var browseCmd = &cobra.Command{
 	Long: `Browse headlines in an interactive TUI.
 
 Use ↑/↓ to navigate, Enter to read, b to go back, c to toggle columns, q to quit.
+Press Ctrl+A to switch to a combined feed of every section, newest first.
 
 Examples:
   economist browse
-  economist browse finance`,
+  economist browse finance
+  economist browse --all`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runBrowse,
 }
 
+var browseAll bool
+
 func init() {
+	browseCmd.Flags().BoolVar(&browseAll, "all", false, "Start in the combined all-sections feed")
 	rootCmd.AddCommand(browseCmd)
 }
 
@@ -36,11 +42,13 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 		return appErrors.NewUserError("browse requires an interactive terminal - use 'headlines --json' for scripts")
 	}
 
-	logging.Debugf(debugMode, "browse: ensure daemon")
-	if err := daemon.EnsureBackground(); err != nil {
-		logging.Debugf(debugMode, "browse: daemon start error: %v", err)
+	if !offline.Enabled() {
+		logging.Debugf(debugMode, "browse: ensure daemon")
+		if err := daemon.EnsureBackground(); err != nil {
+			logging.Debugf(debugMode, "browse: daemon start error: %v", err)
+		}
 	}
-	if debugMode {
+	if debugMode && !offline.Enabled() {
 		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 		defer cancel()
 		if latency, running, err := daemon.Status(ctx); err == nil {
@@ -50,10 +58,14 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if browseAll && len(args) > 0 {
+		return appErrors.NewUserError("use either a section or --all, not both")
+	}
+
 	section := "leaders"
 	if len(args) > 0 {
 		section = args[0]
 	}
 
-	return browse.Run(section, browse.Options{Debug: debugMode, NoColor: noColor})
+	return browse.Run(section, browse.Options{Debug: debugMode, NoColor: noColor, AllSections: browseAll})
 }
@@ -0,0 +1,416 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+var (
+	cacheJSON      bool
+	cacheSection   string
+	cacheOlderThan string
+	cacheArticles  bool
+	cacheFeeds     bool
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect, clear, export and import the local cache",
+	Long: `Manage cached feeds and articles in ~/.config/economist-tui/cache.
+
+Ages accept Go durations such as 90m or 12h, or whole days such as 7d.
+
+Examples:
+  economist cache
+  economist cache ls --section finance
+  economist cache clear --older-than 7d
+  economist cache export cache.tar.gz
+  economist cache import cache.tar.gz`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show entry counts and sizes",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached articles",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheLs,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached entries",
+	Long: `Remove cached feeds and articles. With no flags the whole cache is cleared.
+
+--section only matches articles, so it leaves feeds alone.`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClear,
+}
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Remove expired entries and shrink the cache to its size limit",
+	Args:  cobra.NoArgs,
+	RunE:  runCachePurge,
+}
+
+var cacheMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move JSON cache files into the configured backend",
+	Long: `Import the one-file-per-entry JSON cache into the backend set by
+cache.backend in config.json, then delete the imported files.
+
+Set "backend": "bolt" under "cache" in config.json first.`,
+	Args: cobra.NoArgs,
+	RunE: runCacheMigrate,
+}
+
+var cacheExportCmd = &cobra.Command{
+	Use:   "export <file|->",
+	Short: "Write cached articles to a tar.gz archive",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCacheExport,
+}
+
+var cacheImportCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Load cached articles from a tar.gz archive",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCacheImport,
+}
+
+func init() {
+	cacheCmd.Flags().BoolVar(&cacheJSON, "json", false, "Output JSON")
+	cacheStatsCmd.Flags().BoolVar(&cacheJSON, "json", false, "Output JSON")
+	cacheLsCmd.Flags().BoolVar(&cacheJSON, "json", false, "Output JSON")
+	cacheLsCmd.Flags().StringVar(&cacheSection, "section", "", "Only list articles from this section")
+	cacheLsCmd.Flags().StringVar(&cacheOlderThan, "older-than", "", "Only list entries cached longer ago than this")
+	cacheClearCmd.Flags().StringVar(&cacheSection, "section", "", "Only remove articles from this section")
+	cacheClearCmd.Flags().StringVar(&cacheOlderThan, "older-than", "", "Only remove entries cached longer ago than this")
+	cacheClearCmd.Flags().BoolVar(&cacheArticles, "articles", false, "Only remove articles")
+	cacheClearCmd.Flags().BoolVar(&cacheFeeds, "feeds", false, "Only remove feeds")
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheLsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePurgeCmd)
+	cacheCmd.AddCommand(cacheMigrateCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	cacheCmd.AddCommand(cacheImportCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+type cacheStatsOutput struct {
+	Backend      string `json:"backend"`
+	Path         string `json:"path"`
+	Articles     int    `json:"articles"`
+	ArticleBytes int64  `json:"article_bytes"`
+	Feeds        int    `json:"feeds"`
+	FeedBytes    int64  `json:"feed_bytes"`
+	Quarantined  int    `json:"quarantined,omitempty"`
+	MaxBytes     int64  `json:"max_bytes"`
+}
+
+type cacheEntryOutput struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Section  string `json:"section,omitempty"`
+	CachedAt string `json:"cached_at"`
+	Expired  bool   `json:"expired,omitempty"`
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	usage, err := cache.Stats()
+	if err != nil {
+		return err
+	}
+	maxBytes := cache.CurrentSettings().MaxBytes
+
+	if cacheJSON {
+		return writeJSON(os.Stdout, cacheStatsOutput{
+			Backend:      usage.Backend,
+			Path:         usage.Path,
+			Articles:     usage.Articles,
+			ArticleBytes: usage.ArticleBytes,
+			Feeds:        usage.Feeds,
+			FeedBytes:    usage.FeedBytes,
+			Quarantined:  usage.Quarantined,
+			MaxBytes:     maxBytes,
+		})
+	}
+
+	fmt.Printf("Cache:    %s (%s)\n", usage.Path, usage.Backend)
+	fmt.Printf("Articles: %d (%s)\n", usage.Articles, formatBytes(usage.ArticleBytes))
+	fmt.Printf("Feeds:    %d (%s)\n", usage.Feeds, formatBytes(usage.FeedBytes))
+	fmt.Printf("Total:    %d (%s of %s)\n", usage.Articles+usage.Feeds,
+		formatBytes(usage.ArticleBytes+usage.FeedBytes), formatBytes(maxBytes))
+	if usage.Quarantined > 0 {
+		fmt.Printf("Corrupt:  %d quarantined%s\n", usage.Quarantined, quarantineLocation())
+	}
+	return nil
+}
+
+func runCacheLs(cmd *cobra.Command, args []string) error {
+	cutoff, err := cacheCutoff()
+	if err != nil {
+		return err
+	}
+	query := cache.Query{Before: cutoff}
+	if cacheSection != "" {
+		query.Section = rss.SectionPath(cacheSection)
+	}
+	matched, err := cache.FindArticles(query)
+	if err != nil {
+		return err
+	}
+
+	if cacheJSON {
+		out := make([]cacheEntryOutput, 0, len(matched))
+		for _, entry := range matched {
+			out = append(out, cacheEntryOutput{
+				Title:    entry.Article.Title,
+				URL:      entry.Article.URL,
+				Section:  article.SectionFromURL(entry.Article.URL),
+				CachedAt: entry.CachedAt.Format(time.RFC3339),
+				Expired:  entry.Expired(),
+			})
+		}
+		return writeJSON(os.Stdout, out)
+	}
+
+	if len(matched) == 0 {
+		fmt.Println("No cached articles.")
+		return nil
+	}
+
+	numWidth := len(strconv.Itoa(len(matched)))
+	pad := strings.Repeat(" ", numWidth+2)
+	for i, entry := range matched {
+		title := entry.Article.Title
+		if title == "" {
+			title = entry.Article.URL
+		}
+		fmt.Printf("%*d. %s\n", numWidth, i+1, title)
+
+		details := []string{entry.Article.URL}
+		if section := article.SectionFromURL(entry.Article.URL); section != "" {
+			details = append(details, section)
+		}
+		age := "cached " + formatAge(time.Since(entry.CachedAt)) + " ago"
+		if entry.Expired() {
+			age += " (expired)"
+		}
+		details = append(details, age)
+		fmt.Printf("%s%s\n", pad, strings.Join(details, " · "))
+	}
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	if cacheArticles && cacheFeeds {
+		return appErrors.NewUserError("use --articles or --feeds, not both")
+	}
+	if cacheSection != "" && cacheFeeds {
+		return appErrors.NewUserError("--section only applies to articles")
+	}
+	cutoff, err := cacheCutoff()
+	if err != nil {
+		return err
+	}
+	match, err := cacheArticleFilter()
+	if err != nil {
+		return err
+	}
+
+	var articles, feeds int
+	if !cacheFeeds {
+		if articles, err = cache.RemoveArticles(match); err != nil {
+			return err
+		}
+	}
+	if !cacheArticles && cacheSection == "" {
+		if feeds, err = cache.RemoveFeeds(cutoff); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Removed %d %s and %d %s.\n", articles, plural(articles, "article"), feeds, plural(feeds, "feed"))
+	return nil
+}
+
+func runCachePurge(cmd *cobra.Command, args []string) error {
+	result, err := cache.Purge()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d expired and %d least recently read %s; %s left.\n",
+		result.Expired, result.Evicted, plural(result.Expired+result.Evicted, "entry"), formatBytes(result.Bytes))
+	if result.Quarantined > 0 {
+		fmt.Printf("Quarantined %d corrupt %s%s\n", result.Quarantined, plural(result.Quarantined, "entry"), quarantineLocation())
+	}
+	return nil
+}
+
+func runCacheMigrate(cmd *cobra.Command, args []string) error {
+	if cache.CurrentSettings().Backend == cache.BackendJSON {
+		return appErrors.NewUserError("the cache already uses JSON files - set cache.backend to %q in config.json to migrate", cache.BackendBolt)
+	}
+
+	var sections []string
+	for _, section := range append(rss.AllSections(), rss.EditionPaths()...) {
+		sections = append(sections, rss.SectionPath(section))
+	}
+	result, err := cache.Migrate(sections)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %d %s and %d %s.\n", result.Articles, plural(result.Articles, "article"), result.Feeds, plural(result.Feeds, "feed"))
+	return nil
+}
+
+func runCacheExport(cmd *cobra.Command, args []string) error {
+	if args[0] == "-" {
+		_, err := cache.Export(os.Stdout)
+		return err
+	}
+
+	var count int
+	if err := writeExportFile(args[0], func(w io.Writer) error {
+		var err error
+		count, err = cache.Export(w)
+		return err
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d %s to %s\n", count, plural(count, "article"), args[0])
+	return nil
+}
+
+func runCacheImport(cmd *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			if os.IsNotExist(err) {
+				return appErrors.NewUserError("no such file: %s", args[0])
+			}
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	count, err := cache.Import(r)
+	if err != nil {
+		return appErrors.NewUserError("import failed: %v", err)
+	}
+	fmt.Printf("Imported %d %s.\n", count, plural(count, "article"))
+	return nil
+}
+
+// quarantineLocation names where quarantined entries are kept, for the JSON
+// backend; the bolt backend keeps them inside the database.
+func quarantineLocation() string {
+	if cache.CurrentSettings().Backend == cache.BackendJSON {
+		return " in " + cache.QuarantineDir()
+	}
+	return ""
+}
+
+// cacheArticleFilter builds a match func from --section and --older-than, or
+// returns nil when neither is set.
+func cacheArticleFilter() (func(cache.Entry) bool, error) {
+	cutoff, err := cacheCutoff()
+	if err != nil {
+		return nil, err
+	}
+	section := ""
+	if cacheSection != "" {
+		section = rss.SectionPath(cacheSection)
+	}
+	if section == "" && cutoff.IsZero() {
+		return nil, nil
+	}
+	return func(entry cache.Entry) bool {
+		if section != "" && article.SectionFromURL(entry.Article.URL) != section {
+			return false
+		}
+		return cutoff.IsZero() || entry.CachedAt.Before(cutoff)
+	}, nil
+}
+
+// cacheCutoff turns --older-than into a time, or the zero time when unset.
+func cacheCutoff() (time.Time, error) {
+	if cacheOlderThan == "" {
+		return time.Time{}, nil
+	}
+	age, err := parseAge(cacheOlderThan)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-age), nil
+}
+
+// parseAge reads a Go duration, or a whole number of days such as "7d".
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, appErrors.NewUserError("invalid age %q (use e.g. 12h or 7d)", value)
+	}
+	return age, nil
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	if strings.HasSuffix(word, "y") {
+		return strings.TrimSuffix(word, "y") + "ies"
+	}
+	return word + "s"
+}
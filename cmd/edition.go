@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/browse"
+	"github.com/tmustier/economist-tui/internal/daemon"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
+	"github.com/tmustier/economist-tui/internal/readstate"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var editionJSON bool
+
+var editionCmd = &cobra.Command{
+	Use:   "edition [YYYY-MM-DD|latest]",
+	Short: "Browse a weekly edition in print order",
+	Long: `Browse the articles of one weekly issue, grouped by section in the order of
+the print edition, starting with The world this week and Leaders.
+
+Issues are dated Saturday and cover the week from the Sunday before; any date
+in that week selects the issue. The default is the latest issue. RSS feeds
+only reach back a few weeks, so older issues may be empty.
+
+In the TUI, Tab and Shift+Tab jump between sections.
+
+Examples:
+  economist edition
+  economist edition 2026-01-24
+  economist edition latest --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEdition,
+}
+
+func init() {
+	editionCmd.Flags().BoolVar(&editionJSON, "json", false, "Output JSON")
+	rootCmd.AddCommand(editionCmd)
+}
+
+func runEdition(cmd *cobra.Command, args []string) error {
+	value := "latest"
+	if len(args) > 0 {
+		value = args[0]
+	}
+	issue, err := rss.ParseIssueDate(value)
+	if err != nil {
+		return appErrors.NewUserError("%v", err)
+	}
+
+	if editionJSON {
+		return printEditionJSON(issue)
+	}
+
+	if !ui.IsTerminal(int(os.Stdin.Fd())) {
+		return appErrors.NewUserError("edition requires an interactive terminal - use 'edition --json' for scripts")
+	}
+	if !offline.Enabled() {
+		if err := daemon.EnsureBackground(); err != nil {
+			logging.Debugf(debugMode, "edition: daemon start error: %v", err)
+		}
+	}
+	return browse.Run("", browse.Options{Debug: debugMode, NoColor: noColor, Edition: true, Issue: issue})
+}
+
+type editionOutput struct {
+	Date     string                 `json:"date"`
+	Title    string                 `json:"title"`
+	Sections []editionSectionOutput `json:"sections"`
+}
+
+type editionSectionOutput struct {
+	Section string           `json:"section"`
+	Title   string           `json:"title"`
+	Items   []headlineOutput `json:"items"`
+}
+
+func printEditionJSON(issue time.Time) error {
+	edition, err := rss.FetchEdition(issue)
+	if err != nil {
+		if edition.Len() == 0 {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if edition.Len() == 0 {
+		return editionNotFound(edition)
+	}
+
+	readState, err := readstate.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: read state unavailable: %v\n", err)
+	}
+	return writeJSON(os.Stdout, editionJSONOutput(edition, readState))
+}
+
+func editionNotFound(edition rss.Edition) error {
+	if edition.Date.IsZero() {
+		return appErrors.NewUserError("no dated articles found in the section feeds")
+	}
+	return appErrors.NewUserError("no articles found for the issue of %s - RSS feeds only cover recent weeks", edition.Date.Format("2006-01-02"))
+}
+
+func editionJSONOutput(edition rss.Edition, readState *readstate.State) editionOutput {
+	out := editionOutput{
+		Date:     edition.Date.Format("2006-01-02"),
+		Title:    edition.Title(),
+		Sections: make([]editionSectionOutput, 0, len(edition.Sections)),
+	}
+	var links []string
+	for _, section := range edition.Sections {
+		for _, item := range section.Items {
+			links = append(links, item.Link)
+		}
+	}
+	unavailable := unavailableOffline(links)
+	for _, section := range edition.Sections {
+		items := make([]headlineOutput, 0, len(section.Items))
+		for _, item := range section.Items {
+			items = append(items, headlineOutput{
+				Title:       item.CleanTitle(),
+				Description: item.CleanDescription(),
+				Date:        item.FormattedDate(),
+				PubDate:     item.PubDate,
+				URL:         item.Link,
+				Section:     section.Path,
+				Sections:    []string{section.Path},
+				Read:        readState.IsRead(item.Keys()...),
+				Unavailable: unavailable[item.Link],
+			})
+		}
+		out.Sections = append(out.Sections, editionSectionOutput{Section: section.Path, Title: section.Title, Items: items})
+	}
+	return out
+}
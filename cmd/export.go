@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/export"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+var (
+	exportFormat  string
+	exportSection string
+	exportLimit   int
+	exportOut     string
+	exportNoImage bool
+)
+
+// exportImageTimeout bounds each figure image download for EPUB export.
+const exportImageTimeout = 20 * time.Second
+
+var exportFormats = []string{"epub", "md", "html"}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [format] [url...]",
+	Short: "Export articles as EPUB, Markdown or HTML",
+	Long: `Fetch articles and export them to files.
+
+The format can be given with --format or as the first argument. Pass article
+URLs, or --section to export the latest articles of a section.
+
+Formats:
+  epub  one EPUB 3 book with a table of contents, one chapter per article
+  md    one Markdown file per article, with YAML front matter
+  html  one standalone HTML page per article
+
+Markdown and HTML files are named by article slug and written to --out
+(default: the current directory). EPUB books embed copies of figure images
+(skip with --no-images); Markdown and HTML link to them.
+
+Requires login first: economist login
+
+Examples:
+  economist export --format epub https://www.economist.com/...
+  economist export epub --section leaders -n 10
+  economist export epub --section finance -o ~/Books/
+  economist export --format md --out ~/Notes/Economist <url>
+  economist export html --section leaders --out site/`,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Export format: epub, md or html")
+	exportCmd.Flags().StringVar(&exportSection, "section", "", "Export the latest articles from a section")
+	exportCmd.Flags().IntVarP(&exportLimit, "number", "n", 10, "Number of section articles to export")
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Output directory, or file for epub (default: current directory)")
+	exportCmd.Flags().BoolVar(&exportNoImage, "no-images", false, "Link to figure images instead of embedding them in epub")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, urls, err := resolveExportFormat(args)
+	if err != nil {
+		return err
+	}
+
+	title, subtitle := "", ""
+	if exportSection != "" {
+		if len(urls) > 0 {
+			return appErrors.NewUserError("use either URLs or --section, not both")
+		}
+		feed, err := rss.FetchSection(exportSection)
+		if err != nil {
+			return err
+		}
+		items := feed.Channel.Items
+		if exportLimit > 0 && len(items) > exportLimit {
+			items = items[:exportLimit]
+		}
+		for _, item := range items {
+			urls = append(urls, item.Link)
+		}
+		title = strings.TrimSpace(feed.Channel.Title)
+		subtitle = time.Now().Format("January 2 2006")
+	}
+	if len(urls) == 0 {
+		return appErrors.NewUserError("no articles given - pass URLs or --section")
+	}
+
+	articles := fetchExportArticles(urls)
+	if len(articles) == 0 {
+		return appErrors.NewUserError("no articles could be fetched")
+	}
+
+	if format != "epub" {
+		return exportFiles(articles, format)
+	}
+
+	book := export.Book{
+		Title:    title,
+		Subtitle: subtitle,
+		Articles: articles,
+		Created:  time.Now(),
+	}
+	if !exportNoImage {
+		book.Images = fetchExportImages(articles)
+	}
+	if book.Title == "" {
+		book.Title = articles[0].Title
+		if len(articles) > 1 {
+			book.Title = "The Economist: " + book.Created.Format("January 2 2006")
+		}
+	}
+
+	path, err := exportPath(book.Title, format)
+	if err != nil {
+		return err
+	}
+	if err := writeExportFile(path, func(w io.Writer) error {
+		return export.WriteEPUB(w, book)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d article(s) to %s\n", len(articles), path)
+	return nil
+}
+
+// exportFiles writes one file per article, named by slug, into --out.
+func exportFiles(articles []*article.Article, format string) error {
+	dir := exportOut
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	write := export.WriteMarkdown
+	if format == "html" {
+		write = export.WriteHTML
+	}
+	used := make(map[string]bool)
+	for _, art := range articles {
+		meta := export.Meta{Section: exportSection, FetchedAt: time.Now()}
+		if meta.Section == "" {
+			meta.Section = article.SectionFromURL(art.URL)
+		}
+		if cachedAt, ok := cache.CachedAt(art.URL); ok {
+			meta.FetchedAt = cachedAt
+		}
+
+		path := filepath.Join(dir, uniqueSlug(used, export.Slug(art))+"."+format)
+		if err := writeExportFile(path, func(w io.Writer) error {
+			return write(w, art, meta)
+		}); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+// uniqueSlug returns slug, or slug-2, slug-3 and so on if it is already in
+// used, and records the result.
+func uniqueSlug(used map[string]bool, slug string) string {
+	name := slug
+	for n := 2; used[name]; n++ {
+		name = fmt.Sprintf("%s-%d", slug, n)
+	}
+	used[name] = true
+	return name
+}
+
+func writeExportFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// resolveExportFormat takes the format from --format or a leading argument.
+func resolveExportFormat(args []string) (string, []string, error) {
+	format := exportFormat
+	if len(args) > 0 && !strings.Contains(args[0], "/") {
+		if format != "" && format != args[0] {
+			return "", nil, appErrors.NewUserError("conflicting formats %q and %q", format, args[0])
+		}
+		format = args[0]
+		args = args[1:]
+	}
+	if format == "" {
+		format = exportFormats[0]
+	}
+	if !isExportFormat(format) {
+		return "", nil, appErrors.NewUserError("unknown format %q - use %s", format, strings.Join(exportFormats, ", "))
+	}
+	return format, args, nil
+}
+
+func isExportFormat(name string) bool {
+	for _, format := range exportFormats {
+		if name == format {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchExportArticles(urls []string) []*article.Article {
+	var articles []*article.Article
+	for i, url := range urls {
+		fmt.Fprintf(os.Stderr, "Fetching %d/%d: %s\n", i+1, len(urls), url)
+		art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", url, err)
+			continue
+		}
+		articles = append(articles, art)
+	}
+	return articles
+}
+
+// fetchExportImages downloads figure images for an EPUB. Failed downloads
+// are reported and their figures link to the image instead.
+func fetchExportImages(articles []*article.Article) map[string]article.Image {
+	client := &http.Client{Timeout: exportImageTimeout}
+	images, errs := article.FetchImages(client, articles...)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: image not embedded: %v\n", err)
+	}
+	if len(images) > 0 {
+		fmt.Fprintf(os.Stderr, "Embedded %d image(s)\n", len(images))
+	}
+	return images
+}
+
+// exportPath resolves --out to a file path. Directories, including paths
+// ending in a separator, get a file named after the title.
+func exportPath(title, format string) (string, error) {
+	name := export.Slugify(title) + "." + format
+	if exportOut == "" {
+		return name, nil
+	}
+	if strings.HasSuffix(exportOut, string(os.PathSeparator)) {
+		if err := os.MkdirAll(exportOut, 0755); err != nil {
+			return "", err
+		}
+		return filepath.Join(exportOut, name), nil
+	}
+	if info, err := os.Stat(exportOut); err == nil && info.IsDir() {
+		return filepath.Join(exportOut, name), nil
+	}
+	return exportOut, nil
+}
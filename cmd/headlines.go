@@ -1,13 +1,15 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/offline"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
@@ -17,18 +19,26 @@ var (
 	headlinesSearch string
 	headlinesJSON   bool
 	headlinesPlain  bool
+	headlinesAll    bool
+	headlinesUnread bool
 )
 
 var headlinesCmd = &cobra.Command{
-	Use:   "headlines [section]",
+	Use:   "headlines [section[,section...]]",
 	Short: "Show latest headlines from a section",
 	Long: `Show latest headlines from The Economist RSS feeds.
 
+Pass several comma-separated sections, or --all, to merge feeds. Articles that
+appear in more than one section are listed once, newest first.
+
 Examples:
   economist headlines leaders
   economist headlines finance -n 5
   economist headlines business -s "AI"
-  economist headlines finance --json`,
+  economist headlines finance --json
+  economist headlines finance,business
+  economist headlines --all -n 20
+  economist headlines --all --unread`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runHeadlines,
 }
@@ -38,43 +48,131 @@ func init() {
 	headlinesCmd.Flags().StringVarP(&headlinesSearch, "search", "s", "", "Search headlines for a term")
 	headlinesCmd.Flags().BoolVar(&headlinesJSON, "json", false, "Output JSON")
 	headlinesCmd.Flags().BoolVar(&headlinesPlain, "plain", false, "Output plain text (title\turl)")
+	headlinesCmd.Flags().BoolVar(&headlinesAll, "all", false, "Merge headlines from every section")
+	headlinesCmd.Flags().BoolVar(&headlinesUnread, "unread", false, "Only show articles you have not opened")
 }
 
 func runHeadlines(cmd *cobra.Command, args []string) error {
-	section := "leaders"
-	if len(args) > 0 {
-		section = args[0]
-	}
-
 	if headlinesJSON && headlinesPlain {
 		return appErrors.NewUserError("--json and --plain are mutually exclusive")
 	}
+	if headlinesAll && len(args) > 0 {
+		return appErrors.NewUserError("--all cannot be combined with a section argument")
+	}
+
+	sections := []string{"leaders"}
+	if headlinesAll {
+		sections = rss.AllSections()
+	} else if len(args) > 0 {
+		sections = splitSections(args[0])
+		if len(sections) == 0 {
+			return appErrors.NewUserError("no section given")
+		}
+	}
 
-	items, title, err := fetchHeadlines(section)
+	items, title, err := fetchHeadlines(sections)
 	if err != nil {
 		return err
 	}
 
+	readState, err := readstate.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: read state unavailable: %v\n", err)
+	}
+	if headlinesUnread {
+		items = unreadItems(items, readState)
+	}
+
 	if headlinesJSON {
-		return printHeadlinesJSON(items, section)
+		return printHeadlinesJSON(items, readState)
 	}
 	if headlinesPlain {
 		printHeadlinesPlain(items)
 		return nil
 	}
 
-	printHeadlines(items, title)
+	printHeadlines(items, title, len(sections) > 1, readState)
 	return nil
 }
 
-func fetchHeadlines(section string) ([]rss.Item, string, error) {
+func unreadItems(items []rss.TaggedItem, state *readstate.State) []rss.TaggedItem {
+	unread := make([]rss.TaggedItem, 0, len(items))
+	for _, item := range items {
+		if !state.IsRead(item.Keys()...) {
+			unread = append(unread, item)
+		}
+	}
+	return unread
+}
+
+// unavailableOffline returns which of urls cannot be read because the network
+// is off and the article is neither cached nor saved. It is nil while online.
+func unavailableOffline(urls []string) map[string]bool {
+	if !offline.Enabled() {
+		return nil
+	}
+	available := fetch.Available(urls)
+	unavailable := make(map[string]bool)
+	for _, url := range urls {
+		if !available[url] {
+			unavailable[url] = true
+		}
+	}
+	return unavailable
+}
+
+func itemLinks(items []rss.TaggedItem) []string {
+	links := make([]string, len(items))
+	for i, item := range items {
+		links[i] = item.Link
+	}
+	return links
+}
+
+func splitSections(arg string) []string {
+	var sections []string
+	for _, part := range strings.Split(arg, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			sections = append(sections, part)
+		}
+	}
+	return sections
+}
+
+func fetchHeadlines(sections []string) ([]rss.TaggedItem, string, error) {
+	if len(sections) == 1 {
+		return fetchSectionHeadlines(sections[0])
+	}
+
+	items, err := rss.FetchSections(sections)
+	if err != nil {
+		if len(items) == 0 {
+			return nil, "", err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	label := strings.Join(sections, ", ")
+	title := fmt.Sprintf("Headlines: %s", label)
+	if headlinesAll {
+		label = "all sections"
+		title = "Headlines: all sections"
+	}
+	if headlinesSearch != "" {
+		items = rss.RankTagged(items, headlinesSearch)
+		title = fmt.Sprintf("Search: \"%s\" in %s", headlinesSearch, label)
+	}
+	return items, title, nil
+}
+
+func fetchSectionHeadlines(section string) ([]rss.TaggedItem, string, error) {
 	if headlinesSearch != "" {
 		items, err := rss.Search(section, headlinesSearch)
 		if err != nil {
 			return nil, "", err
 		}
 		title := fmt.Sprintf("Search: \"%s\" in %s", headlinesSearch, section)
-		return items, title, nil
+		return tagItems(items, section), title, nil
 	}
 
 	feed, err := rss.FetchSection(section)
@@ -82,10 +180,18 @@ func fetchHeadlines(section string) ([]rss.Item, string, error) {
 		return nil, "", err
 	}
 	title := strings.TrimSpace(feed.Channel.Title)
-	return feed.Channel.Items, title, nil
+	return tagItems(feed.Channel.Items, section), title, nil
 }
 
-func limitItems(items []rss.Item) []rss.Item {
+func tagItems(items []rss.Item, section string) []rss.TaggedItem {
+	tagged := make([]rss.TaggedItem, len(items))
+	for i, item := range items {
+		tagged[i] = rss.TaggedItem{Item: item, Sections: []string{section}}
+	}
+	return tagged
+}
+
+func limitItems(items []rss.TaggedItem) []rss.TaggedItem {
 	if headlinesLimit > 0 && len(items) > headlinesLimit {
 		return items[:headlinesLimit]
 	}
@@ -93,44 +199,54 @@ func limitItems(items []rss.Item) []rss.Item {
 }
 
 type headlineOutput struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Date        string `json:"date"`
-	PubDate     string `json:"pub_date"`
-	URL         string `json:"url"`
-	Section     string `json:"section"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Date        string   `json:"date"`
+	PubDate     string   `json:"pub_date"`
+	URL         string   `json:"url"`
+	Section     string   `json:"section"`
+	Sections    []string `json:"sections"`
+	Read        bool     `json:"read"`
+	Unavailable bool     `json:"unavailable,omitempty"`
+}
+
+func newHeadlineOutput(item rss.TaggedItem, readState *readstate.State, unavailable map[string]bool) headlineOutput {
+	section := ""
+	if len(item.Sections) > 0 {
+		section = item.Sections[0]
+	}
+	return headlineOutput{
+		Title:       item.CleanTitle(),
+		Description: item.CleanDescription(),
+		Date:        item.FormattedDate(),
+		PubDate:     item.PubDate,
+		URL:         item.Link,
+		Section:     section,
+		Sections:    item.Sections,
+		Read:        readState.IsRead(item.Keys()...),
+		Unavailable: unavailable[item.Link],
+	}
 }
 
-func printHeadlinesJSON(items []rss.Item, section string) error {
+func printHeadlinesJSON(items []rss.TaggedItem, readState *readstate.State) error {
 	items = limitItems(items)
+	unavailable := unavailableOffline(itemLinks(items))
 	out := make([]headlineOutput, 0, len(items))
 	for _, item := range items {
-		out = append(out, headlineOutput{
-			Title:       item.CleanTitle(),
-			Description: item.CleanDescription(),
-			Date:        item.FormattedDate(),
-			PubDate:     item.PubDate,
-			URL:         item.Link,
-			Section:     section,
-		})
+		out = append(out, newHeadlineOutput(item, readState, unavailable))
 	}
 
-	data, err := json.Marshal(out)
-	if err != nil {
-		return err
-	}
-	_, err = os.Stdout.Write(append(data, '\n'))
-	return err
+	return writeJSON(os.Stdout, out)
 }
 
-func printHeadlinesPlain(items []rss.Item) {
+func printHeadlinesPlain(items []rss.TaggedItem) {
 	items = limitItems(items)
 	for _, item := range items {
 		fmt.Printf("%s\t%s\n", item.CleanTitle(), item.Link)
 	}
 }
 
-func printHeadlines(items []rss.Item, title string) {
+func printHeadlines(items []rss.TaggedItem, title string, showSections bool, readState *readstate.State) {
 	termWidth := ui.TermWidth(int(os.Stdout.Fd()))
 	if termWidth <= 0 {
 		termWidth = ui.DefaultWidth
@@ -149,6 +265,7 @@ func printHeadlines(items []rss.Item, title string) {
 		fmt.Println("No articles found.")
 		return
 	}
+	unavailable := unavailableOffline(itemLinks(items))
 
 	numWidth := len(fmt.Sprintf("%d", len(items)))
 	prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, len(items)))
@@ -165,6 +282,12 @@ func printHeadlines(items []rss.Item, title string) {
 			date = item.CompactDate()
 		}
 		dateColumn := fmt.Sprintf("%*s", layout.DateWidth, date)
+		titleStyle := styles.Title
+		subtitleStyle := styles.Subtitle
+		if readState.IsRead(item.Keys()...) {
+			titleStyle = styles.Read
+			subtitleStyle = styles.Read
+		}
 
 		titleLines := ui.WrapLines(headline, layout.TitleWidth)
 		if len(titleLines) == 0 {
@@ -174,8 +297,8 @@ func printHeadlines(items []rss.Item, title string) {
 			if idx == 0 {
 				paddedTitle := fmt.Sprintf("%-*s", layout.TitleWidth, line)
 				fmt.Printf("%s%s%s\n",
-					styles.Title.Render(num),
-					styles.Title.Render(paddedTitle),
+					titleStyle.Render(num),
+					titleStyle.Render(paddedTitle),
 					styles.Dim.Render(dateColumn),
 				)
 				continue
@@ -184,7 +307,7 @@ func printHeadlines(items []rss.Item, title string) {
 				fmt.Printf("%s\n", prefixPad)
 				continue
 			}
-			fmt.Printf("%s%s\n", prefixPad, styles.Title.Render(line))
+			fmt.Printf("%s%s\n", prefixPad, titleStyle.Render(line))
 		}
 
 		if desc := item.CleanDescription(); desc != "" {
@@ -193,11 +316,18 @@ func printHeadlines(items []rss.Item, title string) {
 					fmt.Printf("%s\n", prefixPad)
 					continue
 				}
-				fmt.Printf("%s%s\n", prefixPad, styles.Subtitle.Render(line))
+				fmt.Printf("%s%s\n", prefixPad, subtitleStyle.Render(line))
 			}
 		}
 
-		// URL (dimmed, indented)
-		fmt.Printf("    %s\n\n", styles.Dim.Render(item.Link))
+		// URL (dimmed, indented), prefixed by sections when merging feeds
+		link := item.Link
+		if showSections {
+			link = strings.Join(item.Sections, " · ") + "  " + link
+		}
+		if unavailable[item.Link] {
+			link += "  (not available offline)"
+		}
+		fmt.Printf("    %s\n\n", styles.Dim.Render(link))
 	}
 }
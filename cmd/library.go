@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/library"
+)
+
+var libraryJSON bool
+
+var libraryCmd = &cobra.Command{
+	Use:   "library",
+	Short: "List, show and remove saved articles",
+	Long: `Manage articles saved with 'economist save' or 'read --save'.
+
+Articles are referenced by their number in 'library list' or by URL.
+
+Examples:
+  economist library
+  economist library show 1
+  economist library show 1 --raw
+  economist library rm https://www.economist.com/...`,
+	Args: cobra.NoArgs,
+	RunE: runLibraryList,
+}
+
+var libraryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved articles",
+	Args:  cobra.NoArgs,
+	RunE:  runLibraryList,
+}
+
+var libraryShowCmd = &cobra.Command{
+	Use:   "show <number|url>",
+	Short: "Show a saved article",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runLibraryShow,
+}
+
+var libraryRmCmd = &cobra.Command{
+	Use:   "rm <number|url>",
+	Short: "Remove a saved article",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runLibraryRm,
+}
+
+func init() {
+	libraryCmd.PersistentFlags().BoolVar(&libraryJSON, "json", false, "Output JSON")
+	libraryShowCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw markdown")
+	libraryShowCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "Wrap width for rendered output (0 = auto)")
+	libraryShowCmd.Flags().IntVar(&columns, "columns", 1, "Number of columns for article body (1 or 2)")
+
+	libraryCmd.AddCommand(libraryListCmd)
+	libraryCmd.AddCommand(libraryShowCmd)
+	libraryCmd.AddCommand(libraryRmCmd)
+	rootCmd.AddCommand(libraryCmd)
+}
+
+type libraryOutput struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Date     string `json:"date,omitempty"`
+	URL      string `json:"url"`
+	SavedAt  string `json:"saved_at"`
+}
+
+func runLibraryList(cmd *cobra.Command, args []string) error {
+	entries, err := library.List()
+	if err != nil {
+		return err
+	}
+
+	if libraryJSON {
+		out := make([]libraryOutput, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, libraryOutput{
+				Title:    entry.Article.Title,
+				Subtitle: entry.Article.Subtitle,
+				Date:     entry.Article.DateLine,
+				URL:      entry.Article.URL,
+				SavedAt:  entry.SavedAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+		return writeJSON(os.Stdout, out)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("Library is empty. Save articles with 'economist save <url>'.")
+		return nil
+	}
+
+	numWidth := len(strconv.Itoa(len(entries)))
+	for i, entry := range entries {
+		fmt.Printf("%*d. %s\n", numWidth, i+1, entry.Article.Title)
+		pad := strings.Repeat(" ", numWidth+2)
+		fmt.Printf("%s%s · saved %s\n", pad, entry.Article.URL, entry.SavedAt.Local().Format("Jan 2 2006"))
+	}
+	return nil
+}
+
+func runLibraryShow(cmd *cobra.Command, args []string) error {
+	if columns < 1 || columns > 2 {
+		return appErrors.NewUserError("columns must be 1 or 2")
+	}
+	entry, err := resolveLibraryEntry(args[0])
+	if err != nil {
+		return err
+	}
+	art := entry.Article
+	return outputArticle(&art)
+}
+
+func runLibraryRm(cmd *cobra.Command, args []string) error {
+	entry, err := resolveLibraryEntry(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := library.Remove(entry.Article.URL); err != nil {
+		return err
+	}
+	fmt.Printf("Removed: %s\n", entry.Article.Title)
+	return nil
+}
+
+func resolveLibraryEntry(ref string) (*library.Entry, error) {
+	entries, err := library.List()
+	if err != nil {
+		return nil, err
+	}
+
+	if idx, err := strconv.Atoi(ref); err == nil {
+		if idx < 1 || idx > len(entries) {
+			return nil, appErrors.NewUserError("no saved article #%d - see 'economist library'", idx)
+		}
+		return &entries[idx-1], nil
+	}
+
+	for i := range entries {
+		if entries[i].Article.URL == ref {
+			return &entries[i], nil
+		}
+	}
+	return nil, appErrors.NewUserError("article not in library: %s", ref)
+}
@@ -4,14 +4,20 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tmustier/economist-tui/internal/article"
 	"github.com/tmustier/economist-tui/internal/config"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/readstate"
+	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
@@ -19,8 +25,13 @@ var (
 	rawOutput bool
 	wrapWidth int
 	columns   int
+	readSave  bool
+	readImage bool
 )
 
+// readImageTimeout bounds each figure image download for --images.
+const readImageTimeout = 10 * time.Second
+
 var readCmd = &cobra.Command{
 	Use:   "read [url|-]",
 	Short: "Read an article",
@@ -31,6 +42,8 @@ Requires login first: economist login
 Examples:
   economist read https://www.economist.com/leaders/2026/01/15/some-article
   economist read <url> --raw
+  economist read <url> --save
+  economist read <url> --images
   echo "https://www.economist.com/..." | economist read -`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: runRead,
@@ -40,6 +53,8 @@ func init() {
 	readCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw markdown")
 	readCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "Wrap width for rendered output (0 = auto)")
 	readCmd.Flags().IntVar(&columns, "columns", 1, "Number of columns for article body (1 or 2)")
+	readCmd.Flags().BoolVar(&readSave, "save", false, "Save the article to your library")
+	readCmd.Flags().BoolVar(&readImage, "images", false, "Draw charts inline in terminals with kitty, iTerm2 or sixel graphics")
 }
 
 func runRead(cmd *cobra.Command, args []string) error {
@@ -69,6 +84,16 @@ func runRead(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Debug HTML saved to: %s\n", art.DebugHTMLPath)
 	}
 
+	if err := readstate.MarkRead(rss.Item{Link: url}.Keys()...); err != nil {
+		logging.Debugf(debugMode, "read: mark read error: %v", err)
+	}
+
+	if readSave {
+		if err := library.Save(art); err != nil {
+			return err
+		}
+	}
+
 	return outputArticle(art)
 }
 
@@ -82,6 +107,7 @@ func outputArticle(art *article.Article) error {
 	if ui.IsTerminal(int(os.Stdout.Fd())) {
 		termWidth := ui.TermWidth(int(os.Stdout.Fd()))
 		opts.TermWidth = termWidth
+		opts.Hyperlinks = ui.SupportsHyperlinks()
 		if columns == 1 && wrapWidth == 0 {
 			opts.WrapWidth = ui.ReaderContentWidth(termWidth)
 			opts.Center = true
@@ -89,6 +115,12 @@ func outputArticle(art *article.Article) error {
 		if columns == 2 {
 			opts.Center = true
 		}
+		if readImage && !rawOutput {
+			opts.ImageProtocol = ui.DetectImageProtocol()
+			if opts.ImageProtocol != ui.ImageNone {
+				opts.Images = fetchReadImages(art)
+			}
+		}
 	}
 
 	out, err := ui.RenderArticle(art, opts)
@@ -100,6 +132,16 @@ func outputArticle(art *article.Article) error {
 	return nil
 }
 
+// fetchReadImages downloads the article's figure images. Figures whose image
+// cannot be fetched keep their text placeholder.
+func fetchReadImages(art *article.Article) map[string]article.Image {
+	images, errs := article.FetchImages(&http.Client{Timeout: readImageTimeout}, art)
+	for _, err := range errs {
+		logging.Debugf(debugMode, "read: image error: %v", err)
+	}
+	return images
+}
+
 func resolveURL(args []string) (string, error) {
 	if len(args) == 1 && args[0] != "-" {
 		return args[0], nil
@@ -1,19 +1,30 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/config"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
 )
 
 var (
-	debugMode bool
-	noColor   bool
-	version   = "0.4.0"
-	commit    = ""
-	date      = ""
+	debugMode   bool
+	noColor     bool
+	offlineMode bool
+	articleTTL  time.Duration
+	rssTTL      time.Duration
+	cacheMaxMB  int
+	version     = "0.4.0"
+	commit      = ""
+	date        = ""
 )
 
 var rootCmd = &cobra.Command{
@@ -22,6 +33,12 @@ var rootCmd = &cobra.Command{
 	Long:          `A terminal UI and CLI to browse and read articles from The Economist.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if offlineMode {
+			offline.Enable()
+		}
+		return configureCache()
+	},
 }
 
 func Execute() {
@@ -35,6 +52,51 @@ func Execute() {
 	}
 }
 
+// configureCache applies the cache settings from config.json, with flags
+// taking precedence.
+func configureCache() error {
+	cfg, err := config.Load()
+	if err != nil {
+		logging.Debugf(debugMode, "config load error: %v", err)
+		cfg = &config.Config{}
+	}
+
+	settings := cache.Settings{MaxBytes: int64(cfg.Cache.MaxSizeMB) << 20, Backend: cfg.Cache.Backend}
+	switch settings.Backend {
+	case "", cache.BackendJSON, cache.BackendBolt:
+	default:
+		return appErrors.NewUserError("invalid cache.backend %q in config.json - use %q or %q", settings.Backend, cache.BackendJSON, cache.BackendBolt)
+	}
+	if settings.ArticleTTL, err = parseTTL("article_ttl", cfg.Cache.ArticleTTL); err != nil {
+		return err
+	}
+	if settings.RSSTTL, err = parseTTL("rss_ttl", cfg.Cache.RSSTTL); err != nil {
+		return err
+	}
+	if articleTTL > 0 {
+		settings.ArticleTTL = articleTTL
+	}
+	if rssTTL > 0 {
+		settings.RSSTTL = rssTTL
+	}
+	if cacheMaxMB > 0 {
+		settings.MaxBytes = int64(cacheMaxMB) << 20
+	}
+	cache.Configure(settings)
+	return nil
+}
+
+func parseTTL(key, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, appErrors.NewUserError("invalid cache.%s %q in config.json - use a duration such as 1h or 90s", key, value)
+	}
+	return ttl, nil
+}
+
 func buildVersion() string {
 	v := version
 	if commit != "" {
@@ -57,6 +119,10 @@ func init() {
 	noColor = detectNoColor()
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", noColor, "Disable color output")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "Read only from the cache and library, without the network")
+	rootCmd.PersistentFlags().DurationVar(&articleTTL, "article-ttl", 0, "How long cached articles stay fresh (default 1h, or cache.article_ttl in config)")
+	rootCmd.PersistentFlags().DurationVar(&rssTTL, "rss-ttl", 0, "How long cached feeds stay fresh (default 2m, or cache.rss_ttl in config)")
+	rootCmd.PersistentFlags().IntVar(&cacheMaxMB, "cache-max-mb", 0, "Maximum cache size in MB (default 200, or cache.max_size_mb in config)")
 	rootCmd.Version = buildVersion()
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 
@@ -65,3 +131,13 @@ func init() {
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(sectionsCmd)
 }
+
+// writeJSON writes v to w as one line of JSON.
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/library"
+)
+
+var saveCmd = &cobra.Command{
+	Use:   "save [url|-]",
+	Short: "Save an article to your library",
+	Long: `Fetch an article and keep it in your local library.
+
+Saved articles never expire and can be read offline.
+
+Examples:
+  economist save https://www.economist.com/leaders/2026/01/15/some-article
+  economist headlines finance --json | jq -r '.[0].url' | economist save -`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runSave,
+}
+
+func init() {
+	rootCmd.AddCommand(saveCmd)
+}
+
+func runSave(cmd *cobra.Command, args []string) error {
+	url, err := resolveURL(args)
+	if err != nil {
+		return err
+	}
+
+	art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+	if err != nil {
+		return err
+	}
+
+	if err := library.Save(art); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved: %s\n", art.Title)
+	return nil
+}
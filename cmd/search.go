@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/index"
+	"github.com/tmustier/economist-tui/internal/search"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	searchLimit   int
+	searchJSON    bool
+	searchRebuild bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the full text of fetched and saved articles",
+	Long: `Search article bodies you have already read or saved.
+
+The search index is built from the article cache and your library. Articles
+stay searchable, including after their TTL, until they leave both.
+
+Queries use the headline search syntax: quoted phrases, -exclusions and OR.
+
+Examples:
+  economist search '"central bank"'
+  economist search "china OR india -tariffs" -n 5
+  economist search semiconductors --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "number", "n", 10, "Number of results to show")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output JSON")
+	searchCmd.Flags().BoolVar(&searchRebuild, "rebuild", false, "Rebuild the index from scratch")
+	rootCmd.AddCommand(searchCmd)
+}
+
+type searchOutput struct {
+	Title   string  `json:"title"`
+	URL     string  `json:"url"`
+	Date    string  `json:"date,omitempty"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+	Saved   bool    `json:"saved"`
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		return appErrors.NewUserError("search query is empty")
+	}
+
+	update := index.Update
+	if searchRebuild {
+		update = index.Rebuild
+	}
+	ix, err := update()
+	if err != nil {
+		return err
+	}
+
+	results := ix.Match(search.Parse(query), searchLimit)
+	if searchJSON {
+		out := make([]searchOutput, 0, len(results))
+		for _, result := range results {
+			out = append(out, searchOutput{
+				Title:   result.Title,
+				URL:     result.URL,
+				Date:    result.DateLine,
+				Score:   result.Score,
+				Snippet: result.Snippet,
+				Saved:   result.Saved,
+			})
+		}
+		return writeJSON(os.Stdout, out)
+	}
+
+	printSearchResults(query, results, len(ix.Docs))
+	return nil
+}
+
+func printSearchResults(query string, results []index.Result, docCount int) {
+	termWidth := ui.TermWidth(int(os.Stdout.Fd()))
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	contentWidth := ui.ReaderContentWidth(termWidth)
+
+	styles := ui.NewBrowseStyles(noColor)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), noColor)
+
+	fmt.Printf("%s\n", styles.Header.Render(fmt.Sprintf("Search: \"%s\" in %d articles", query, docCount)))
+	fmt.Printf("%s\n\n", ui.AccentRule(contentWidth, accentStyles))
+
+	if len(results) == 0 {
+		fmt.Println("No articles found.")
+		return
+	}
+
+	numWidth := len(fmt.Sprintf("%d", len(results)))
+	prefixPad := strings.Repeat(" ", numWidth+2)
+	textWidth := contentWidth - len(prefixPad)
+	for i, result := range results {
+		for idx, line := range ui.WrapLines(result.Title, textWidth) {
+			if idx == 0 {
+				fmt.Printf("%s%s\n", styles.Title.Render(fmt.Sprintf("%*d. ", numWidth, i+1)), styles.Title.Render(line))
+				continue
+			}
+			fmt.Printf("%s%s\n", prefixPad, styles.Title.Render(line))
+		}
+		for _, line := range ui.WrapLines(result.Snippet, textWidth) {
+			fmt.Printf("%s%s\n", prefixPad, styles.Subtitle.Render(line))
+		}
+		label := result.URL
+		if result.Saved {
+			label += " · saved"
+		}
+		fmt.Printf("%s%s\n\n", prefixPad, styles.Dim.Render(label))
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+)
+
+func TestSearchCommandParsesQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, art := range []*article.Article{
+		{URL: "https://example.com/china", Title: "China", Content: "China's exports rose."},
+		{URL: "https://example.com/india", Title: "India", Content: "India's exports rose despite tariffs."},
+		{URL: "https://example.com/japan", Title: "Japan", Content: "Japan's exports fell."},
+	} {
+		if err := cache.SaveArticle(art); err != nil {
+			t.Fatalf("cache save: %v", err)
+		}
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		stdout := os.Stdout
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		os.Stdout = w
+		rootCmd.SetArgs([]string{"search", "--json", query})
+		runErr := rootCmd.Execute()
+		os.Stdout = stdout
+		w.Close()
+		data, _ := io.ReadAll(r)
+		if runErr != nil {
+			t.Fatalf("search %q: %v", query, runErr)
+		}
+
+		var results []searchOutput
+		if err := json.Unmarshal(data, &results); err != nil {
+			t.Fatalf("decode %q: %v", data, err)
+		}
+		var urls []string
+		for _, result := range results {
+			urls = append(urls, result.URL)
+		}
+		return urls
+	}
+
+	if got := search("china OR india"); len(got) != 2 {
+		t.Fatalf("expected OR to match either article, got %v", got)
+	}
+	if got := search("exports -tariffs"); len(got) != 2 || got[0] == "https://example.com/india" || got[1] == "https://example.com/india" {
+		t.Fatalf("expected the excluded article dropped, got %v", got)
+	}
+}
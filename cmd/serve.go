@@ -7,13 +7,16 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/config"
 	"github.com/tmustier/economist-tui/internal/daemon"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 )
 
 var (
-	serveStatus bool
-	serveStop   bool
+	serveStatus  bool
+	serveStop    bool
+	serveWorkers int
+	serveFeeds   time.Duration
 )
 
 var serveCmd = &cobra.Command{
@@ -22,10 +25,20 @@ var serveCmd = &cobra.Command{
 	Long: `Run a local daemon that keeps a headless browser warm.
 
 The daemon listens on a local Unix socket and speeds up article reads.
+Several articles are fetched at once, each in its own browser tab; set the
+number with --workers or "fetch_workers" in config.json (default 3).
+
+While browsing, the headlines on screen are prefetched into the cache in the
+background. Prefetching pauses whenever an article is being opened.
+
+Every section feed is refreshed every 10 minutes (--feed-interval, 0 turns it
+off), so browsing starts from a warm cache. Unchanged feeds cost a 304.
 
 Examples:
   economist serve
   economist serve &
+  economist serve --workers 5
+  economist serve --feed-interval 30m
   economist serve --status
   economist serve --stop`,
 	RunE: runServe,
@@ -34,6 +47,8 @@ Examples:
 func init() {
 	serveCmd.Flags().BoolVar(&serveStatus, "status", false, "Show daemon status")
 	serveCmd.Flags().BoolVar(&serveStop, "stop", false, "Stop the daemon")
+	serveCmd.Flags().IntVar(&serveWorkers, "workers", 0, "Number of articles to fetch at once (default: fetch_workers from config, or 3)")
+	serveCmd.Flags().DurationVar(&serveFeeds, "feed-interval", daemon.DefaultFeedInterval, "How often to refresh every section feed (0 = never)")
 	rootCmd.AddCommand(serveCmd)
 }
 
@@ -71,6 +86,19 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	workers := serveWorkers
+	if workers <= 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		workers = cfg.FetchWorkers
+	}
+
 	fmt.Println("Starting economist serve daemon...")
-	return daemon.Serve()
+	feedInterval := serveFeeds
+	if offlineMode {
+		feedInterval = 0
+	}
+	return daemon.Serve(daemon.ServeOptions{Workers: workers, FeedInterval: feedInterval, Debug: debugMode})
 }
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/offline"
+	"github.com/tmustier/economist-tui/internal/readstate"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/watch"
+)
+
+// watchHookTimeout bounds how long one --exec command may run.
+const watchHookTimeout = 30 * time.Second
+
+var (
+	watchInterval        time.Duration
+	watchSearch          string
+	watchNDJSON          string
+	watchExec            string
+	watchOnce            bool
+	watchIncludeExisting bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [section[,section...]...]",
+	Short: "Report new headlines as they appear",
+	Long: `Poll section feeds and report headlines that have not been seen before.
+
+Seen headlines are kept in ~/.config/economist-tui/watch.json, so a restart
+picks up where the last run stopped. The first poll of a section only records
+what is already there; pass --include-existing to report it too. With no
+sections, every section is watched.
+
+Each new headline is printed, appended to --ndjson as one JSON object per line
+(the same shape as 'headlines --json'), and/or piped as JSON to the --exec
+command's stdin. --search filters headlines with the headline search syntax.
+
+Examples:
+  economist watch
+  economist watch leaders finance --interval 5m
+  economist watch --search "china OR tariffs" --ndjson news.ndjson
+  economist watch --exec 'curl -s -d @- https://ntfy.sh/my-topic'
+  economist watch --once --ndjson -`,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 10*time.Minute, "Time between polls")
+	watchCmd.Flags().StringVarP(&watchSearch, "search", "s", "", "Only report headlines matching this query")
+	watchCmd.Flags().StringVar(&watchNDJSON, "ndjson", "", "Append new headlines as JSON lines to this file (- for stdout)")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "Run this shell command for each new headline, with its JSON on stdin")
+	watchCmd.Flags().BoolVar(&watchOnce, "once", false, "Poll once and exit")
+	watchCmd.Flags().BoolVar(&watchIncludeExisting, "include-existing", false, "Report headlines already in a feed the first time it is polled")
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchInterval < time.Minute && !watchOnce {
+		return appErrors.NewUserError("--interval must be at least 1m")
+	}
+
+	var sections []string
+	for _, arg := range args {
+		sections = append(sections, splitSections(arg)...)
+	}
+	if len(sections) == 0 {
+		sections = rss.AllSections()
+	}
+
+	state, err := watch.Load()
+	if err != nil {
+		return fmt.Errorf("load watch state: %w", err)
+	}
+	watcher := watch.NewWatcher(watch.Options{
+		Sections:        sections,
+		Query:           watchSearch,
+		IncludeExisting: watchIncludeExisting,
+	}, state)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		// A network failure switches the process to offline mode; watch
+		// outlives it, so each poll tries the network again.
+		if !offlineMode {
+			offline.Disable()
+		}
+		items, err := watcher.Poll()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		// Save only once the items are reported, so a failed report
+		// repeats them on the next run rather than losing them.
+		if err := reportWatchItems(ctx, items); err != nil {
+			return err
+		}
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("save watch state: %w", err)
+		}
+
+		if watchOnce {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func reportWatchItems(ctx context.Context, items []rss.TaggedItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	readState, err := readstate.Load()
+	if err != nil {
+		readState = nil
+	}
+
+	var ndjson *os.File
+	switch watchNDJSON {
+	case "":
+	case "-":
+		ndjson = os.Stdout
+	default:
+		ndjson, err = os.OpenFile(watchNDJSON, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer ndjson.Close()
+	}
+
+	unavailable := unavailableOffline(itemLinks(items))
+	for _, item := range items {
+		out := newHeadlineOutput(item, readState, unavailable)
+		if watchNDJSON != "-" {
+			printWatchItem(item)
+		}
+		if ndjson != nil {
+			if err := writeJSON(ndjson, out); err != nil {
+				return err
+			}
+		}
+		if watchExec != "" {
+			var hookInput bytes.Buffer
+			if err := writeJSON(&hookInput, out); err != nil {
+				return err
+			}
+			if err := runWatchHook(ctx, watchExec, hookInput.Bytes()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: --exec failed for %s: %v\n", item.Link, err)
+			}
+		}
+	}
+	return nil
+}
+
+func printWatchItem(item rss.TaggedItem) {
+	section := ""
+	if len(item.Sections) > 0 {
+		section = item.Sections[0]
+	}
+	fmt.Printf("%s  [%s] %s\n", time.Now().Format("15:04"), section, item.CleanTitle())
+	fmt.Printf("       %s\n", item.Link)
+}
+
+// runWatchHook runs command through the shell with data on stdin.
+func runWatchHook(ctx context.Context, command string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, watchHookTimeout)
+	defer cancel()
+
+	var hook *exec.Cmd
+	if runtime.GOOS == "windows" {
+		hook = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		hook = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	hook.Stdin = bytes.NewReader(data)
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+	return hook.Run()
+}
@@ -9,8 +9,10 @@ import (
 type ScreenID string
 
 const (
-	ScreenBrowse ScreenID = "browse"
-	ScreenAll    ScreenID = "all-sections"
+	ScreenBrowse    ScreenID = "browse"
+	ScreenAll       ScreenID = "all-sections"
+	ScreenBookmarks ScreenID = "bookmarks"
+	ScreenEdition   ScreenID = "edition"
 )
 
 type ScreenBuilder func() tea.Model
@@ -24,6 +26,7 @@ type Host struct {
 	current  ScreenID
 	builders map[ScreenID]ScreenBuilder
 	screens  map[ScreenID]tea.Model
+	size     *tea.WindowSizeMsg
 	err      error
 }
 
@@ -49,6 +52,8 @@ func (h Host) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case SwitchScreenMsg:
 		return h.switchTo(msg)
+	case tea.WindowSizeMsg:
+		h.size = &msg
 	}
 
 	model := h.currentModel()
@@ -93,11 +98,16 @@ func (h Host) switchTo(msg SwitchScreenMsg) (tea.Model, tea.Cmd) {
 				return h, nil
 			}
 			model = builder()
-			h.screens[msg.ID] = model
 		}
+		// Screens built or resumed after a resize never saw it.
+		var sizeCmd tea.Cmd
+		if h.size != nil {
+			model, sizeCmd = model.Update(*h.size)
+		}
+		h.screens[msg.ID] = model
 		h.current = msg.ID
 		h.err = nil
-		return h, model.Init()
+		return h, tea.Batch(model.Init(), sizeCmd)
 	}
 	return h, nil
 }
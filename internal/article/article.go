@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -25,6 +26,8 @@ const (
 	articleWaitTimeout   = 8 * time.Second
 )
 
+// blockedURLPatterns keeps page loads fast. Figure images are not needed to
+// parse an article: their URLs are read from the markup (see imageSource).
 var blockedURLPatterns = []string{
 	"*.png",
 	"*.jpg",
@@ -60,7 +63,12 @@ type Article struct {
 	Title         string
 	Subtitle      string
 	DateLine      string
+	Authors       []string
+	Published     time.Time
+	Section       string
+	WordCount     int
 	Content       string
+	Blocks        []Block
 	URL           string
 	DebugHTMLPath string
 }
@@ -78,11 +86,19 @@ func Fetch(articleURL string, opts FetchOptions) (*Article, error) {
 }
 
 func FetchWithCookies(articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, error) {
+	return FetchWithContext(context.Background(), articleURL, opts, cookies)
+}
+
+// FetchWithContext loads the article in a new tab of the shared browser. The
+// tab is closed as soon as parent is cancelled.
+func FetchWithContext(parent context.Context, articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, error) {
 	start := time.Now()
 
 	baseCtx := browser.SharedHeadlessContext(opts.Debug)
 	ctx, cancel := chromedp.NewContext(baseCtx)
 	defer cancel()
+	stop := context.AfterFunc(parent, cancel)
+	defer stop()
 
 	ctx, cancel = context.WithTimeout(ctx, browser.FetchTimeout)
 	defer cancel()
@@ -142,27 +158,63 @@ func parseArticle(html, articleURL string) (*Article, error) {
 		return nil, err
 	}
 
-	article := &Article{URL: articleURL}
-	article.Overtitle = findFirst(
-		doc,
-		".article__overline",
-		".article__overline-link",
-		"[data-test-id='overline']",
-		".article__kicker",
-		".article__section",
-		".article__section-headline",
-		".article__headline-overline",
-	)
+	// The embedded JSON-LD and Next.js data come first; the markup selectors
+	// fill in whatever they lack.
+	data := extractEmbedded(doc)
+	article := &Article{
+		URL:       articleURL,
+		Overtitle: data.Overtitle,
+		Title:     data.Headline,
+		Subtitle:  data.Description,
+		Authors:   data.Authors,
+		Published: data.Published,
+		Section:   data.Section,
+		WordCount: data.WordCount,
+	}
+	if article.Overtitle == "" {
+		article.Overtitle = findFirst(
+			doc,
+			".article__overline",
+			".article__overline-link",
+			"[data-test-id='overline']",
+			".article__kicker",
+			".article__section",
+			".article__section-headline",
+			".article__headline-overline",
+		)
+	}
 	if article.Overtitle == "" {
 		article.Overtitle = extractHeaderOvertitle(doc)
 	}
-	article.Title = findFirst(doc, "h1.article__headline", "[data-test-id='headline']", "article h1", "h1")
-	article.Subtitle = findFirst(doc, ".article__description", "[data-test-id='subheadline']", ".article__subheadline", "h2.article__description", "header h2", "section h2")
+	if article.Title == "" {
+		article.Title = findFirst(doc, "h1.article__headline", "[data-test-id='headline']", "article h1", "h1")
+	}
+	if article.Subtitle == "" {
+		article.Subtitle = findFirst(doc, ".article__description", "[data-test-id='subheadline']", ".article__subheadline", "h2.article__description", "header h2", "section h2")
+	}
 	if article.Subtitle == "" {
 		article.Subtitle = extractFirstHeading(doc, "h2")
 	}
-	article.DateLine = strings.TrimSpace(doc.Find("time").First().Text())
-	article.Content = extractContent(doc)
+	if !article.Published.IsZero() {
+		article.DateLine = FormatDateLine(article.Published)
+	} else {
+		article.DateLine = strings.TrimSpace(doc.Find("time").First().Text())
+	}
+
+	// JSON-LD only has the body as plain text, so the structured markup is
+	// preferred to it.
+	article.Blocks = data.Blocks
+	if len(article.Blocks) == 0 {
+		article.Blocks = extractContent(doc)
+	}
+	if len(article.Blocks) == 0 && data.Body != "" {
+		article.Blocks = trimTrailingBlocks((&Article{Content: data.Body}).BodyBlocks())
+	}
+	resolveLinks(article.Blocks, articleURL)
+	article.Content = PlainText(article.Blocks)
+	if article.WordCount == 0 {
+		article.WordCount = len(strings.Fields(article.Content))
+	}
 
 	if err := checkPaywall(html, article.Content); err != nil {
 		return article, err
@@ -171,6 +223,43 @@ func parseArticle(html, articleURL string) (*Article, error) {
 	return article, nil
 }
 
+// FormatDateLine formats t the way the site dates articles, as in
+// "Jan 22nd 2026".
+func FormatDateLine(t time.Time) string {
+	day := t.Day()
+	return fmt.Sprintf("%s %d%s %d", t.Format("Jan"), day, ordinalSuffix(day), t.Year())
+}
+
+func ordinalSuffix(day int) string {
+	if day%100 >= 11 && day%100 <= 13 {
+		return "th"
+	}
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// SectionFromURL returns the section path of an Economist article URL, such
+// as "finance-and-economics".
+func SectionFromURL(articleURL string) string {
+	u, err := url.Parse(articleURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
+
 func findFirst(doc *goquery.Document, selectors ...string) string {
 	for _, sel := range selectors {
 		if text := cleanHeaderText(doc.Find(sel).First().Text()); text != "" {
@@ -232,45 +321,28 @@ func cleanHeaderText(text string) string {
 	return strings.Join(strings.Fields(text), " ")
 }
 
-func extractContent(doc *goquery.Document) string {
-	var paragraphs []string
-
+// extractContent returns the article body as blocks, cut at the
+// end-of-article marker.
+func extractContent(doc *goquery.Document) []Block {
 	// Primary selectors for article body
-	doc.Find(".article__body-text p, [data-component='article-body'] p").Each(func(i int, s *goquery.Selection) {
-		if isInsideRelatedSection(s) {
-			return
-		}
-		if text := cleanParagraph(s); text != "" {
-			paragraphs = append(paragraphs, text)
-		}
-	})
+	blocks := extractBlocks(doc.Find(".article__body-text, [data-component='article-body']"))
 
 	// Fallback to broader selectors
-	if len(paragraphs) == 0 {
+	if len(blocks) == 0 {
 		doc.Find("article p, main p").Each(func(i int, s *goquery.Selection) {
-			if text := cleanParagraph(s); text != "" && !looksLikeTeaser(text) {
-				paragraphs = append(paragraphs, text)
+			if block, ok := parseBlock(s); ok && !looksLikeTeaser(SpansText(block.Spans)) {
+				blocks = append(blocks, block)
 			}
 		})
 	}
 
-	content := strings.Join(paragraphs, "\n\n")
-	content = strings.TrimSpace(content)
-	return trimTrailingMarker(content)
+	return trimTrailingBlocks(blocks)
 }
 
 func isInsideRelatedSection(s *goquery.Selection) bool {
 	return s.ParentsFiltered("[class*='related'], [class*='teaser'], [class*='promo']").Length() > 0
 }
 
-func cleanParagraph(s *goquery.Selection) string {
-	text := strings.TrimSpace(s.Text())
-	if len(text) < minParagraphLen || isBoilerplate(text) {
-		return ""
-	}
-	return text
-}
-
 // looksLikeTeaser detects short promotional text that isn't article content.
 func looksLikeTeaser(text string) bool {
 	// Real article paragraphs are typically longer
@@ -300,35 +372,6 @@ func isBoilerplate(text string) bool {
 	return false
 }
 
-func trimTrailingMarker(content string) string {
-	const marker = "■"
-	if !strings.Contains(content, marker) {
-		return content
-	}
-
-	paragraphs := strings.Split(content, "\n\n")
-	if len(paragraphs) == 0 {
-		return content
-	}
-
-	start := len(paragraphs) - 3
-	if start < 0 {
-		start = 0
-	}
-
-	for i := len(paragraphs) - 1; i >= start; i-- {
-		idx := strings.LastIndex(paragraphs[i], marker)
-		if idx == -1 {
-			continue
-		}
-
-		paragraphs[i] = strings.TrimSpace(paragraphs[i][:idx+len(marker)])
-		return strings.TrimSpace(strings.Join(paragraphs[:i+1], "\n\n"))
-	}
-
-	return content
-}
-
 var paywallIndicators = []string{
 	"Subscribe to read",
 	"Keep reading with a subscription",
@@ -436,7 +479,7 @@ func (a *Article) ToMarkdown() string {
 	}
 
 	sb.WriteString("---\n\n")
-	sb.WriteString(a.Content)
+	sb.WriteString(a.BodyMarkdown())
 	sb.WriteString("\n\n---\n")
 	sb.WriteString(a.URL)
 	sb.WriteString("\n")
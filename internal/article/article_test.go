@@ -5,7 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 )
 
@@ -66,3 +68,225 @@ func TestParseArticlePaywall(t *testing.T) {
 		t.Fatalf("expected paywall error, got %v", err)
 	}
 }
+
+func TestParseArticleReadsNextData(t *testing.T) {
+	html := loadFixture(t, "nextdata.html")
+	art, err := parseArticle(html, "https://www.economist.com/finance-and-economics/2026/01/22/last-mile")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	if art.Title != "Central banks and the last mile" || art.Overtitle != "Inflation" || art.Subtitle != "Why the final stretch is the hardest" {
+		t.Fatalf("expected Next.js headline fields, got %q / %q / %q", art.Overtitle, art.Title, art.Subtitle)
+	}
+	if art.Section != "Finance & economics" || art.DateLine != "Jan 22nd 2026" || !art.Published.Equal(time.Date(2026, 1, 22, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected section and date, got %q %q %v", art.Section, art.DateLine, art.Published)
+	}
+	// Fields the Next.js data lacks come from JSON-LD.
+	if len(art.Authors) != 1 || art.Authors[0] != "The Economist" || art.WordCount != 812 {
+		t.Fatalf("expected JSON-LD authors and word count, got %v %d", art.Authors, art.WordCount)
+	}
+
+	if len(art.Blocks) != 3 || art.Blocks[1].Kind != BlockHeading {
+		t.Fatalf("expected paragraph, heading, paragraph from body nodes, got %+v", art.Blocks)
+	}
+	if links := art.Links(); len(links) != 1 || links[0].URL != "https://www.economist.com/finance-and-economics/2026/01/01/rates" {
+		t.Fatalf("expected resolved body link, got %+v", links)
+	}
+	if strings.Contains(art.Content, "markup paragraph") || !strings.HasSuffix(art.Content, "■") {
+		t.Fatalf("expected embedded body cut at marker, got %q", art.Content)
+	}
+}
+
+func TestParseArticleReadsJSONLD(t *testing.T) {
+	html := loadFixture(t, "jsonld.html")
+	art, err := parseArticle(html, "https://www.economist.com/business/2026/02/03/strait")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	if art.Title != "Shipping through the strait" || art.Subtitle != "Insurers are pricing in a longer disruption" {
+		t.Fatalf("expected JSON-LD headline and description, got %q / %q", art.Title, art.Subtitle)
+	}
+	if strings.Join(art.Authors, ", ") != "Jane Doe, John Roe" || art.Section != "Business" || art.WordCount != 640 {
+		t.Fatalf("expected authors, section and word count, got %v %q %d", art.Authors, art.Section, art.WordCount)
+	}
+	if art.DateLine != "Feb 3rd 2026" {
+		t.Fatalf("expected date line from datePublished, got %q", art.DateLine)
+	}
+	want := "Container rates have doubled since the attacks began in the autumn.\n\nInsurers now charge war-risk premiums on every transit of the strait. ■"
+	if art.Content != want {
+		t.Fatalf("expected articleBody paragraphs\n%q, got\n%q", want, art.Content)
+	}
+}
+
+func TestParseArticleFallsBackToSelectors(t *testing.T) {
+	html := loadFixture(t, "basic.html")
+	art, err := parseArticle(html, "https://example.com/test")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	// Without embedded data the word count is counted from the content and
+	// no publish time is known.
+	if art.WordCount != len(strings.Fields(art.Content)) || !art.Published.IsZero() {
+		t.Fatalf("expected word count from content and no publish time, got %d %v", art.WordCount, art.Published)
+	}
+}
+
+func TestParseArticleExtractsBlocks(t *testing.T) {
+	html := loadFixture(t, "structured.html")
+	art, err := parseArticle(html, "https://example.com/structured")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	var kinds []BlockKind
+	for _, block := range art.Blocks {
+		kinds = append(kinds, block.Kind)
+	}
+	want := []BlockKind{BlockParagraph, BlockHeading, BlockQuote, BlockList, BlockFigure, BlockPullQuote, BlockTable, BlockParagraph}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected kinds %v, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("expected kinds %v, got %v", want, kinds)
+		}
+	}
+
+	opening := art.Blocks[0].Spans
+	if len(opening) != 7 || !opening[1].Bold || opening[1].Text != "bold words" || !opening[3].Italic || opening[3].Text != "italic phrase" {
+		t.Fatalf("expected bold and italic spans, got %+v", opening)
+	}
+	if opening[5].Text != "link" || opening[5].Link != "https://example.com/finance" {
+		t.Fatalf("expected resolved link span, got %+v", opening[5])
+	}
+	if got := SpansText(opening); got != "The opening paragraph has bold words, an italic phrase and a link in its running text." {
+		t.Fatalf("expected collapsed whitespace, got %q", got)
+	}
+	if heading := art.Blocks[1]; heading.Level != 2 || SpansText(heading.Spans) != "A subheading" {
+		t.Fatalf("expected level 2 heading, got %+v", heading)
+	}
+	if list := art.Blocks[3]; len(list.Items) != 2 || list.Ordered || !list.Items[1][1].Bold {
+		t.Fatalf("expected unordered list with bold span, got %+v", list)
+	}
+	figures := art.Figures()
+	if len(figures) != 1 {
+		t.Fatalf("expected one figure, got %+v", figures)
+	}
+	wantFigure := Figure{Index: 4, Src: "https://example.com/img/chart-1280.png", Alt: "Bar chart of GDP growth", Caption: "Growth by country", Credit: "Chart: The Economist"}
+	if figures[0] != wantFigure {
+		t.Fatalf("expected figure %+v, got %+v", wantFigure, figures[0])
+	}
+	if got := figures[0].Placeholder(); got != "[Chart: Growth by country]" {
+		t.Fatalf("expected chart placeholder, got %q", got)
+	}
+	if !strings.Contains(art.Content, "[Chart: Growth by country]") {
+		t.Fatalf("expected placeholder in content, got %q", art.Content)
+	}
+	if links := art.Links(); len(links) != 2 || links[1].URL != wantFigure.Src || links[1].Text != "[Chart: Growth by country]" {
+		t.Fatalf("expected figure among links, got %+v", links)
+	}
+	if table := art.Blocks[6]; !table.Header || len(table.Rows) != 2 || table.Rows[1][1] != "1.1%" {
+		t.Fatalf("expected table with header row, got %+v", table)
+	}
+	if last := SpansText(art.Blocks[7].Spans); !strings.HasSuffix(last, "■") {
+		t.Fatalf("expected body cut at marker, got %q", last)
+	}
+
+	if !strings.Contains(art.Content, "A subheading\n\nA quotation") || !strings.Contains(art.Content, "• First point\n• Second point") {
+		t.Fatalf("expected plain-text content from blocks, got %q", art.Content)
+	}
+}
+
+func TestToMarkdownRendersBlocks(t *testing.T) {
+	art := &Article{
+		Title: "Headline",
+		URL:   "https://example.com/a",
+		Blocks: []Block{
+			{Kind: BlockParagraph, Spans: []Span{{Text: "Some "}, {Text: "bold ", Bold: true}, {Text: "and "}, {Text: "italic", Italic: true}, {Text: " text_with*marks."}}},
+			{Kind: BlockHeading, Level: 3, Spans: []Span{{Text: "Subhead"}}},
+			{Kind: BlockParagraph, Spans: []Span{{Text: "Read "}, {Text: "our ", Link: "https://example.com/a (b)"}, {Text: "briefing ", Bold: true, Link: "https://example.com/a (b)"}, {Text: "now."}}},
+			{Kind: BlockQuote, Spans: []Span{{Text: "Quoted."}}},
+			{Kind: BlockList, Ordered: true, Items: [][]Span{{{Text: "One"}}, {{Text: "Two"}}}},
+			{Kind: BlockTable, Header: true, Rows: [][]string{{"A", "B"}, {"1", "2|3"}}},
+			{Kind: BlockFigure, Src: "https://example.com/chart.png", Alt: "Bar chart", Spans: []Span{{Text: "Growth"}}, Credit: "Chart: The Economist"},
+		},
+	}
+
+	md := art.ToMarkdown()
+	for _, want := range []string{
+		`Some **bold** and _italic_ text\_with\*marks.`,
+		"### Subhead",
+		"Read [our **briefing**](https://example.com/a%20%28b%29) now.",
+		"> Quoted.",
+		"1. One\n2. Two",
+		"| A | B |\n| --- | --- |\n| 1 | 2\\|3 |",
+		"![Bar chart](https://example.com/chart.png)\n_Growth_ Chart: The Economist",
+	} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected %q in:\n%s", want, md)
+		}
+	}
+
+	if terminal := art.TerminalMarkdown(); !strings.Contains(terminal, "text_with*marks.") {
+		t.Fatalf("expected unescaped terminal markdown, got %q", terminal)
+	}
+
+	legacy := &Article{Title: "Old", Content: "Plain *text* paragraph."}
+	if !strings.Contains(legacy.ToMarkdown(), "Plain *text* paragraph.") {
+		t.Fatalf("expected legacy content passed through, got %q", legacy.ToMarkdown())
+	}
+}
+
+func TestMarkdownLinkEscapesText(t *testing.T) {
+	got := MarkdownLink("Why [some] rates (still) matter", "https://example.com/a (b)")
+	want := `[Why \[some\] rates (still) matter](https://example.com/a%20%28b%29)`
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestParseFigure(t *testing.T) {
+	cases := []struct {
+		name string
+		html string
+		want Figure
+	}{
+		{
+			name: "lazy image with credit-only caption",
+			html: `<figure><img src="data:image/gif;base64,AA==" data-src="/a.jpg" alt="A port"><figcaption>Photograph: Getty Images</figcaption></figure>`,
+			want: Figure{Src: "/a.jpg", Alt: "A port", Credit: "Photograph: Getty Images"},
+		},
+		{
+			name: "picture source and credit outside the caption",
+			html: `<figure><picture><source srcset="/m.webp 1x, /m@2x.webp 2x"><img alt=""></picture><figcaption>Where the border runs</figcaption><small>Map: OpenStreetMap</small></figure>`,
+			want: Figure{Src: "/m@2x.webp", Caption: "Where the border runs", Credit: "Map: OpenStreetMap"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tc.html))
+			if err != nil {
+				t.Fatalf("parse html: %v", err)
+			}
+			block, ok := parseFigure(doc.Find("figure"))
+			if !ok {
+				t.Fatalf("expected a figure")
+			}
+			if got := block.Figure(); got != tc.want {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSectionFromURL(t *testing.T) {
+	if got := SectionFromURL("https://www.economist.com/finance-and-economics/2026/01/22/the-last-mile"); got != "finance-and-economics" {
+		t.Fatalf("expected section from URL, got %q", got)
+	}
+	if got := SectionFromURL("https://www.economist.com/leaders"); got != "" {
+		t.Fatalf("expected no section for a section page, got %q", got)
+	}
+}
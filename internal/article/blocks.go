@@ -0,0 +1,596 @@
+package article
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// BlockKind is the type of a body block.
+type BlockKind string
+
+const (
+	BlockHeading   BlockKind = "heading"
+	BlockParagraph BlockKind = "paragraph"
+	BlockQuote     BlockKind = "quote"
+	BlockPullQuote BlockKind = "pullquote"
+	BlockList      BlockKind = "list"
+	BlockFigure    BlockKind = "figure"
+	BlockTable     BlockKind = "table"
+)
+
+// Span is a run of inline text with one formatting. Link is the absolute
+// URL the text points to, if any.
+type Span struct {
+	Text   string `json:"text"`
+	Bold   bool   `json:"bold,omitempty"`
+	Italic bool   `json:"italic,omitempty"`
+	Link   string `json:"link,omitempty"`
+}
+
+// Link is a hyperlink in the article body.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Block is one element of an article body. Which fields are set depends on
+// Kind: Spans for headings, paragraphs, quotes and figure captions, Items for
+// lists, Rows for tables, Src, Alt and Credit for figures.
+type Block struct {
+	Kind    BlockKind `json:"kind"`
+	Level   int       `json:"level,omitempty"`
+	Spans   []Span    `json:"spans,omitempty"`
+	Items   [][]Span  `json:"items,omitempty"`
+	Ordered bool      `json:"ordered,omitempty"`
+	// Rows holds table cells; the first row is the header when Header is set.
+	Rows   [][]string `json:"rows,omitempty"`
+	Header bool       `json:"header,omitempty"`
+	Src    string     `json:"src,omitempty"`
+	Alt    string     `json:"alt,omitempty"`
+	Credit string     `json:"credit,omitempty"`
+}
+
+// Figure is an image in the article body. Index is the position of its block
+// in Article.Blocks.
+type Figure struct {
+	Index   int
+	Src     string
+	Alt     string
+	Caption string
+	Credit  string
+}
+
+// Figure returns the figure a BlockFigure block describes.
+func (b Block) Figure() Figure {
+	return Figure{Src: b.Src, Alt: b.Alt, Caption: SpansText(b.Spans), Credit: b.Credit}
+}
+
+// Label names the kind of image: "Chart", "Map" or "Image".
+func (f Figure) Label() string {
+	for _, text := range []string{f.Credit, f.Caption, f.Alt} {
+		lower := strings.ToLower(text)
+		switch {
+		case strings.HasPrefix(lower, "chart") || strings.Contains(lower, " chart"):
+			return "Chart"
+		case strings.HasPrefix(lower, "map") || strings.Contains(lower, " map "):
+			return "Map"
+		}
+	}
+	return "Image"
+}
+
+// Placeholder is the text shown in place of the image, such as
+// "[Chart: Growth by country]".
+func (f Figure) Placeholder() string {
+	text := f.Caption
+	if text == "" {
+		text = f.Alt
+	}
+	if text == "" {
+		return "[" + f.Label() + "]"
+	}
+	return "[" + f.Label() + ": " + text + "]"
+}
+
+// Text returns the block as plain text. List items are put on their own
+// lines with a bullet or number, table cells are separated by " | " and
+// figures become placeholders.
+func (b Block) Text() string {
+	switch b.Kind {
+	case BlockList:
+		lines := make([]string, 0, len(b.Items))
+		for i, item := range b.Items {
+			marker := "•"
+			if b.Ordered {
+				marker = fmt.Sprintf("%d.", i+1)
+			}
+			lines = append(lines, marker+" "+SpansText(item))
+		}
+		return strings.Join(lines, "\n")
+	case BlockTable:
+		lines := make([]string, 0, len(b.Rows))
+		for _, row := range b.Rows {
+			lines = append(lines, strings.Join(row, " | "))
+		}
+		return strings.Join(lines, "\n")
+	case BlockFigure:
+		return b.Figure().Placeholder()
+	default:
+		return SpansText(b.Spans)
+	}
+}
+
+// SpansText joins the text of spans.
+func SpansText(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+// PlainText joins the plain text of blocks with blank lines, the format of
+// Article.Content.
+func PlainText(blocks []Block) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if text := strings.TrimSpace(block.Text()); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// BodyBlocks returns the structured body, or for articles cached before
+// blocks were recorded, one paragraph per paragraph of Content.
+func (a *Article) BodyBlocks() []Block {
+	if len(a.Blocks) > 0 {
+		return a.Blocks
+	}
+	var blocks []Block
+	for _, para := range strings.Split(a.Content, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			blocks = append(blocks, Block{Kind: BlockParagraph, Spans: []Span{{Text: para}}})
+		}
+	}
+	return blocks
+}
+
+// Figures returns the images in the body, in order.
+func (a *Article) Figures() []Figure {
+	var figures []Figure
+	for i, block := range a.Blocks {
+		if block.Kind == BlockFigure {
+			figure := block.Figure()
+			figure.Index = i
+			figures = append(figures, figure)
+		}
+	}
+	return figures
+}
+
+// Links returns the body's hyperlinks in order of first appearance, one per
+// URL. A figure contributes its image, under its placeholder text, in place
+// of the links in its caption.
+func (a *Article) Links() []Link {
+	var links []Link
+	seen := make(map[string]bool)
+	add := func(link Link) {
+		if !seen[link.URL] {
+			seen[link.URL] = true
+			links = append(links, link)
+		}
+	}
+	for _, block := range a.Blocks {
+		if block.Kind == BlockFigure {
+			if block.Src != "" {
+				figure := block.Figure()
+				add(Link{Text: figure.Placeholder(), URL: figure.Src})
+			}
+			continue
+		}
+		eachSpans([]Block{block}, func(spans []Span) {
+			for i := 0; i < len(spans); i++ {
+				link := spans[i].Link
+				if link == "" {
+					continue
+				}
+				text := spans[i].Text
+				for i+1 < len(spans) && spans[i+1].Link == link {
+					i++
+					text += spans[i].Text
+				}
+				add(Link{Text: text, URL: link})
+			}
+		})
+	}
+	return links
+}
+
+// eachSpans calls fn with every run of spans in blocks: block text, list
+// items and captions.
+func eachSpans(blocks []Block, fn func(spans []Span)) {
+	for _, block := range blocks {
+		if len(block.Spans) > 0 {
+			fn(block.Spans)
+		}
+		for _, item := range block.Items {
+			fn(item)
+		}
+	}
+}
+
+// resolveLinks makes span links and figure sources absolute against
+// articleURL and drops the links that do not lead to a web page, such as
+// in-page anchors.
+func resolveLinks(blocks []Block, articleURL string) {
+	base, err := url.Parse(articleURL)
+	if err != nil {
+		base = &url.URL{}
+	}
+	eachSpans(blocks, func(spans []Span) {
+		for i := range spans {
+			if spans[i].Link != "" {
+				spans[i].Link = resolveURL(base, spans[i].Link)
+			}
+		}
+	})
+	for i := range blocks {
+		if blocks[i].Src != "" {
+			blocks[i].Src = resolveURL(base, blocks[i].Src)
+		}
+	}
+}
+
+// resolveURL returns raw as an absolute http(s) URL, or "" if it is not one.
+func resolveURL(base *url.URL, raw string) string {
+	ref, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	resolved := base.ResolveReference(ref)
+	if (resolved.Scheme != "http" && resolved.Scheme != "https") || resolved.Host == "" {
+		return ""
+	}
+	if ref.Scheme == "" && ref.Host == "" && ref.Path == "" && ref.Fragment != "" {
+		return ""
+	}
+	return resolved.String()
+}
+
+const blockSelector = "h2, h3, h4, p, blockquote, ul, ol, figure, table, aside"
+
+// extractBlocks walks the body containers in document order and converts
+// each top-level block element. Elements nested in a block already taken,
+// such as a paragraph inside a blockquote, belong to that block.
+func extractBlocks(containers *goquery.Selection) []Block {
+	var blocks []Block
+	taken := make(map[*html.Node]bool)
+	visit := func(s *goquery.Selection) {
+		node := s.Get(0)
+		for parent := node.Parent; parent != nil; parent = parent.Parent {
+			if taken[parent] {
+				return
+			}
+		}
+		taken[node] = true
+		if isInsideRelatedSection(s) {
+			return
+		}
+		if block, ok := parseBlock(s); ok {
+			blocks = append(blocks, block)
+		}
+	}
+
+	containers.Each(func(_ int, container *goquery.Selection) {
+		if container.Is(blockSelector) {
+			visit(container)
+			return
+		}
+		container.Find(blockSelector).Each(func(_ int, s *goquery.Selection) {
+			visit(s)
+		})
+	})
+	return blocks
+}
+
+func parseBlock(s *goquery.Selection) (Block, bool) {
+	switch goquery.NodeName(s) {
+	case "h2", "h3", "h4":
+		spans := inlineSpans(s)
+		if text := SpansText(spans); text == "" || isBoilerplate(text) {
+			return Block{}, false
+		}
+		level := int(goquery.NodeName(s)[1] - '0')
+		return Block{Kind: BlockHeading, Level: level, Spans: spans}, true
+	case "p":
+		spans := inlineSpans(s)
+		if text := SpansText(spans); len(text) < minParagraphLen || isBoilerplate(text) {
+			return Block{}, false
+		}
+		return Block{Kind: BlockParagraph, Spans: spans}, true
+	case "blockquote", "aside":
+		kind := BlockQuote
+		if isPullQuote(s) {
+			kind = BlockPullQuote
+		} else if goquery.NodeName(s) == "aside" {
+			return Block{}, false
+		}
+		spans := inlineSpans(s)
+		if SpansText(spans) == "" {
+			return Block{}, false
+		}
+		return Block{Kind: kind, Spans: spans}, true
+	case "ul", "ol":
+		var items [][]Span
+		s.ChildrenFiltered("li").Each(func(_ int, li *goquery.Selection) {
+			if spans := inlineSpans(li); len(spans) > 0 {
+				items = append(items, spans)
+			}
+		})
+		if len(items) == 0 {
+			return Block{}, false
+		}
+		return Block{Kind: BlockList, Items: items, Ordered: goquery.NodeName(s) == "ol"}, true
+	case "figure":
+		if isPullQuote(s) || s.Find("blockquote").Length() > 0 {
+			spans := inlineSpans(s.Find("blockquote").First())
+			if len(spans) == 0 {
+				spans = inlineSpans(s)
+			}
+			if len(spans) == 0 {
+				return Block{}, false
+			}
+			return Block{Kind: BlockPullQuote, Spans: spans}, true
+		}
+		if table := s.Find("table").First(); table.Length() > 0 {
+			return parseTable(table)
+		}
+		return parseFigure(s)
+	case "table":
+		return parseTable(s)
+	}
+	return Block{}, false
+}
+
+// creditSelector matches the photo or chart credit inside a figure.
+const creditSelector = "[class*='credit'], [class*='Credit'], small, cite"
+
+// creditPattern matches a caption that is only a credit, such as
+// "Photograph: Getty Images" or "Chart: The Economist".
+var creditPattern = regexp.MustCompile(`^(?i)(photograph|photo|illustration|image|chart|map|graphic|source)s?:\s`)
+
+// parseFigure records an image with its alt text, caption and credit. The
+// credit is kept apart from the caption when it is marked up as such or when
+// it is all the caption holds.
+func parseFigure(s *goquery.Selection) (Block, bool) {
+	img := s.Find("img").First()
+	alt, _ := img.Attr("alt")
+	block := Block{Kind: BlockFigure, Src: imageSource(s, img), Alt: collapseSpace(strings.TrimSpace(alt))}
+
+	caption := s.Find("figcaption").First().Clone()
+	credit := caption.Find(creditSelector).First()
+	if credit.Length() == 0 {
+		credit = s.Find(creditSelector).First()
+	}
+	block.Credit = collapseSpace(strings.TrimSpace(credit.Text()))
+	caption.Find(creditSelector).Remove()
+	block.Spans = inlineSpans(caption)
+	if block.Credit == "" && creditPattern.MatchString(SpansText(block.Spans)) {
+		block.Credit = SpansText(block.Spans)
+		block.Spans = nil
+	}
+
+	if block.Src == "" && len(block.Spans) == 0 && block.Credit == "" {
+		return Block{}, false
+	}
+	return block, true
+}
+
+// imageSource returns the URL of a figure's image. Lazily loaded images
+// keep a placeholder in src until they are shown, and images are never
+// loaded while fetching, so data-src and the largest srcset candidate are
+// preferred over a data: URI.
+func imageSource(figure, img *goquery.Selection) string {
+	if src, ok := img.Attr("data-src"); ok && strings.TrimSpace(src) != "" {
+		return strings.TrimSpace(src)
+	}
+	srcset, _ := img.Attr("srcset")
+	if srcset == "" {
+		srcset, _ = img.Attr("data-srcset")
+	}
+	if srcset == "" {
+		srcset, _ = figure.Find("picture source[srcset]").First().Attr("srcset")
+	}
+	if src := largestCandidate(srcset); src != "" {
+		return src
+	}
+	src := strings.TrimSpace(img.AttrOr("src", ""))
+	if strings.HasPrefix(src, "data:") {
+		return ""
+	}
+	return src
+}
+
+// largestCandidate returns the widest image in a srcset attribute, or the
+// last one when they have no width descriptors.
+func largestCandidate(srcset string) string {
+	best, bestWidth := "", -1
+	for _, candidate := range strings.Split(srcset, ", ") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "data:") {
+			continue
+		}
+		width := 0
+		if len(fields) > 1 && strings.HasSuffix(fields[1], "w") {
+			width, _ = strconv.Atoi(strings.TrimSuffix(fields[1], "w"))
+		}
+		if width >= bestWidth {
+			best, bestWidth = fields[0], width
+		}
+	}
+	return best
+}
+
+func isPullQuote(s *goquery.Selection) bool {
+	class, _ := s.Attr("class")
+	component, _ := s.Attr("data-component")
+	marker := strings.ToLower(class + " " + component)
+	return strings.Contains(marker, "pullquote") || strings.Contains(marker, "pull-quote")
+}
+
+func parseTable(s *goquery.Selection) (Block, bool) {
+	block := Block{Kind: BlockTable}
+	s.Find("tr").Each(func(i int, tr *goquery.Selection) {
+		var row []string
+		tr.ChildrenFiltered("th, td").Each(func(_ int, cell *goquery.Selection) {
+			row = append(row, cleanHeaderText(cell.Text()))
+		})
+		if len(row) == 0 {
+			return
+		}
+		if len(block.Rows) == 0 && tr.ChildrenFiltered("th").Length() > 0 {
+			block.Header = true
+		}
+		block.Rows = append(block.Rows, row)
+	})
+	return block, len(block.Rows) > 0
+}
+
+// inlineSpans flattens the text under s into spans, tracking bold, italic
+// and link runs and collapsing whitespace as a browser would. Links are left
+// as written; resolveLinks makes them absolute.
+func inlineSpans(s *goquery.Selection) []Span {
+	var spans []Span
+	var walk func(node *html.Node, style Span)
+	walk = func(node *html.Node, style Span) {
+		switch node.Type {
+		case html.TextNode:
+			style.Text = node.Data
+			spans = appendSpan(spans, style)
+			return
+		case html.ElementNode:
+			switch node.Data {
+			case "script", "style", "noscript", "svg", "button":
+				return
+			case "br":
+				style.Text = " "
+				spans = appendSpan(spans, style)
+				return
+			case "b", "strong":
+				style.Bold = true
+			case "i", "em", "cite":
+				style.Italic = true
+			case "a":
+				for _, attr := range node.Attr {
+					if attr.Key == "href" {
+						style.Link = attr.Val
+					}
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, style)
+		}
+	}
+	for _, node := range s.Nodes {
+		walk(node, Span{})
+	}
+	return trimSpans(spans)
+}
+
+// appendSpan adds span to spans, merging it into the last span when the
+// formatting matches and collapsing runs of whitespace.
+func appendSpan(spans []Span, span Span) []Span {
+	span.Text = collapseSpace(span.Text)
+	if span.Text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 {
+		last := &spans[n-1]
+		if strings.HasSuffix(last.Text, " ") {
+			span.Text = strings.TrimLeft(span.Text, " ")
+			if span.Text == "" {
+				return spans
+			}
+		}
+		if last.Bold == span.Bold && last.Italic == span.Italic && last.Link == span.Link {
+			last.Text += span.Text
+			return spans
+		}
+	}
+	return append(spans, span)
+}
+
+func collapseSpace(text string) string {
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	if text == "" {
+		return ""
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return " "
+	}
+	out := strings.Join(fields, " ")
+	if isSpace(text[0]) {
+		out = " " + out
+	}
+	if isSpace(text[len(text)-1]) {
+		out += " "
+	}
+	return out
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// trimSpans strips leading and trailing whitespace from the spans as a whole.
+func trimSpans(spans []Span) []Span {
+	for len(spans) > 0 {
+		spans[0].Text = strings.TrimLeft(spans[0].Text, " ")
+		if spans[0].Text != "" {
+			break
+		}
+		spans = spans[1:]
+	}
+	for len(spans) > 0 {
+		last := len(spans) - 1
+		spans[last].Text = strings.TrimRight(spans[last].Text, " ")
+		if spans[last].Text != "" {
+			break
+		}
+		spans = spans[:last]
+	}
+	return spans
+}
+
+// trimTrailingBlocks cuts the body after the end-of-article marker when it
+// appears in one of the last three blocks.
+func trimTrailingBlocks(blocks []Block) []Block {
+	const marker = "■"
+	start := len(blocks) - 3
+	if start < 0 {
+		start = 0
+	}
+	for i := len(blocks) - 1; i >= start; i-- {
+		spans := blocks[i].Spans
+		for j := len(spans) - 1; j >= 0; j-- {
+			idx := strings.LastIndex(spans[j].Text, marker)
+			if idx == -1 {
+				continue
+			}
+			trimmed := append([]Span(nil), spans[:j+1]...)
+			trimmed[j].Text = strings.TrimRight(spans[j].Text[:idx+len(marker)], " ")
+			blocks[i].Spans = trimmed
+			return blocks[:i+1]
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,338 @@
+package article
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// embedded is the article data a page carries for search engines (JSON-LD)
+// and for its own scripts (Next.js). Unlike the markup, these payloads keep
+// their shape across redesigns.
+type embedded struct {
+	Overtitle   string
+	Headline    string
+	Description string
+	Authors     []string
+	Published   time.Time
+	Section     string
+	WordCount   int
+	// Blocks is the structured body, from Next.js data.
+	Blocks []Block
+	// Body is the plain-text body, from JSON-LD articleBody.
+	Body string
+}
+
+// merge fills the fields of e that are empty from other.
+func (e *embedded) merge(other embedded) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&e.Overtitle, other.Overtitle)
+	fill(&e.Headline, other.Headline)
+	fill(&e.Description, other.Description)
+	fill(&e.Section, other.Section)
+	fill(&e.Body, other.Body)
+	if len(e.Authors) == 0 {
+		e.Authors = other.Authors
+	}
+	if e.Published.IsZero() {
+		e.Published = other.Published
+	}
+	if e.WordCount == 0 {
+		e.WordCount = other.WordCount
+	}
+	if len(e.Blocks) == 0 {
+		e.Blocks = other.Blocks
+	}
+}
+
+// extractEmbedded reads the Next.js payload, then JSON-LD for what it lacks.
+func extractEmbedded(doc *goquery.Document) embedded {
+	data := parseNextData(doc)
+	data.merge(parseJSONLD(doc))
+	return data
+}
+
+// parseJSONLD reads the first NewsArticle in the page's JSON-LD scripts.
+func parseJSONLD(doc *goquery.Document) embedded {
+	var data embedded
+	doc.Find("script[type='application/ld+json']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var payload any
+		if err := json.Unmarshal([]byte(s.Text()), &payload); err != nil {
+			return true
+		}
+		for _, obj := range ldObjects(payload) {
+			if isNewsArticle(obj) {
+				data = embedded{
+					Headline:    jsonString(obj["headline"]),
+					Description: jsonString(obj["description"]),
+					Authors:     jsonNames(obj["author"]),
+					Published:   jsonTime(obj["datePublished"]),
+					Section:     jsonName(obj["articleSection"]),
+					WordCount:   jsonInt(obj["wordCount"]),
+					Body:        plainBody(obj["articleBody"]),
+				}
+				return false
+			}
+		}
+		return true
+	})
+	return data
+}
+
+// ldObjects flattens JSON-LD arrays and @graph lists into their objects.
+func ldObjects(payload any) []map[string]any {
+	switch v := payload.(type) {
+	case []any:
+		var objs []map[string]any
+		for _, item := range v {
+			objs = append(objs, ldObjects(item)...)
+		}
+		return objs
+	case map[string]any:
+		objs := []map[string]any{v}
+		if graph, ok := v["@graph"]; ok {
+			objs = append(objs, ldObjects(graph)...)
+		}
+		return objs
+	}
+	return nil
+}
+
+func isNewsArticle(obj map[string]any) bool {
+	types := []any{obj["@type"]}
+	if list, ok := obj["@type"].([]any); ok {
+		types = list
+	}
+	for _, t := range types {
+		if name, ok := t.(string); ok && strings.HasSuffix(name, "NewsArticle") {
+			return true
+		}
+	}
+	return false
+}
+
+// plainBody keeps one paragraph per non-empty line, in Content's format.
+func plainBody(v any) string {
+	body, _ := v.(string)
+	var paras []string
+	for _, line := range strings.Split(body, "\n") {
+		if line = cleanHeaderText(line); line != "" {
+			paras = append(paras, line)
+		}
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+// nextArticleDepth bounds the search for the article in the Next.js props.
+const nextArticleDepth = 8
+
+// parseNextData reads the article from the page's __NEXT_DATA__ props: the
+// first object holding a headline and a body.
+func parseNextData(doc *goquery.Document) embedded {
+	script := doc.Find("script#__NEXT_DATA__").First()
+	if script.Length() == 0 {
+		return embedded{}
+	}
+	var payload struct {
+		Props struct {
+			PageProps any `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err := json.Unmarshal([]byte(script.Text()), &payload); err != nil {
+		return embedded{}
+	}
+	obj := findNextArticle(payload.Props.PageProps, nextArticleDepth)
+	if obj == nil {
+		return embedded{}
+	}
+
+	authors := jsonNames(obj["authors"])
+	if len(authors) == 0 {
+		authors = jsonNames(obj["author"])
+	}
+	if byline := strings.TrimPrefix(jsonString(obj["byline"]), "By "); len(authors) == 0 && byline != "" {
+		authors = []string{byline}
+	}
+	return embedded{
+		Overtitle:   jsonString(obj["flyTitle"]),
+		Headline:    jsonString(obj["headline"]),
+		Description: firstString(obj, "rubric", "description", "subheadline"),
+		Authors:     authors,
+		Published:   jsonTime(obj["datePublished"]),
+		Section:     firstName(obj, "section", "articleSection"),
+		WordCount:   jsonInt(firstValue(obj, "wordCount", "wordcount")),
+		Blocks:      nextBlocks(obj["body"]),
+	}
+}
+
+func findNextArticle(v any, depth int) map[string]any {
+	if depth < 0 {
+		return nil
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := v["headline"].(string); ok && v["body"] != nil {
+			return v
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if found := findNextArticle(v[key], depth-1); found != nil {
+				return found
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if found := findNextArticle(child, depth-1); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// nextBlocks parses a Next.js body, either an HTML string or a tree of
+// {type, name, attribs, children, data} nodes, into blocks.
+func nextBlocks(body any) []Block {
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	if text, ok := body.(string); ok {
+		nodes, err := html.ParseFragment(strings.NewReader(text), root)
+		if err != nil {
+			return nil
+		}
+		for _, node := range nodes {
+			if node.Parent != nil {
+				node.Parent.RemoveChild(node)
+			}
+			root.AppendChild(node)
+		}
+	} else {
+		appendNextNodes(root, body)
+	}
+	blocks := extractBlocks(goquery.NewDocumentFromNode(root).Selection)
+	return trimTrailingBlocks(blocks)
+}
+
+func appendNextNodes(parent *html.Node, v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, child := range v {
+			appendNextNodes(parent, child)
+		}
+	case map[string]any:
+		switch jsonString(v["type"]) {
+		case "text":
+			if text, ok := v["data"].(string); ok {
+				parent.AppendChild(&html.Node{Type: html.TextNode, Data: text})
+			}
+		case "tag":
+			name := strings.ToLower(jsonString(v["name"]))
+			if name == "" {
+				return
+			}
+			node := &html.Node{Type: html.ElementNode, Data: name, DataAtom: atom.Lookup([]byte(name))}
+			if attribs, ok := v["attribs"].(map[string]any); ok {
+				for key, val := range attribs {
+					if s, ok := val.(string); ok {
+						node.Attr = append(node.Attr, html.Attribute{Key: key, Val: s})
+					}
+				}
+			}
+			appendNextNodes(node, v["children"])
+			parent.AppendChild(node)
+		}
+	}
+}
+
+func firstValue(obj map[string]any, keys ...string) any {
+	for _, key := range keys {
+		if v, ok := obj[key]; ok && v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func firstString(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s := jsonString(obj[key]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func firstName(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s := jsonName(obj[key]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func jsonString(v any) string {
+	s, _ := v.(string)
+	return cleanHeaderText(s)
+}
+
+// jsonName reads a name given as a string, an object with a name, or a list
+// of either, of which the first is used.
+func jsonName(v any) string {
+	if names := jsonNames(v); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func jsonNames(v any) []string {
+	switch v := v.(type) {
+	case string:
+		if name := cleanHeaderText(v); name != "" {
+			return []string{name}
+		}
+	case map[string]any:
+		return jsonNames(v["name"])
+	case []any:
+		var names []string
+		for _, item := range v {
+			names = append(names, jsonNames(item)...)
+		}
+		return names
+	}
+	return nil
+}
+
+func jsonInt(v any) int {
+	switch v := v.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
+
+func jsonTime(v any) time.Time {
+	s := jsonString(v)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,92 @@
+package article
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/browser"
+)
+
+// maxImageSize bounds a single downloaded figure image.
+const maxImageSize = 10 << 20
+
+// imageExtensions maps the image types e-readers support to file extensions.
+var imageExtensions = map[string]string{
+	"image/jpeg":    ".jpg",
+	"image/png":     ".png",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/svg+xml": ".svg",
+}
+
+// Image is a downloaded figure image.
+type Image struct {
+	MediaType string
+	Data      []byte
+}
+
+// FetchImages downloads the figure images of articles, keyed by URL. Images
+// that fail to download are left out and reported in the returned errors.
+func FetchImages(client *http.Client, articles ...*Article) (map[string]Image, []error) {
+	images := make(map[string]Image)
+	var errs []error
+	for _, art := range articles {
+		for _, figure := range art.Figures() {
+			if figure.Src == "" {
+				continue
+			}
+			if _, ok := images[figure.Src]; ok {
+				continue
+			}
+			image, err := FetchImage(client, figure.Src)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			images[figure.Src] = image
+		}
+	}
+	return images, errs
+}
+
+// FetchImage downloads an image, accepting the types in imageExtensions.
+func FetchImage(client *http.Client, url string) (Image, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return Image{}, err
+	}
+	req.Header.Set("User-Agent", browser.UserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return Image{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Image{}, fmt.Errorf("HTTP %d from %s", resp.StatusCode, url)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return Image{}, err
+	}
+	if len(data) > maxImageSize {
+		return Image{}, fmt.Errorf("image too large: %s", url)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if _, ok := imageExtensions[mediaType]; !ok {
+		mediaType = strings.SplitN(http.DetectContentType(data), ";", 2)[0]
+	}
+	if _, ok := imageExtensions[mediaType]; !ok {
+		return Image{}, fmt.Errorf("unsupported image type %q: %s", mediaType, url)
+	}
+	return Image{MediaType: mediaType, Data: data}, nil
+}
+
+// Extension returns the file extension for the image's type, such as ".png".
+func (i Image) Extension() string {
+	return imageExtensions[i.MediaType]
+}
@@ -0,0 +1,211 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BodyMarkdown renders the body blocks as Markdown.
+func (a *Article) BodyMarkdown() string {
+	return markdownRenderer{escape: true}.body(a)
+}
+
+// TerminalMarkdown renders the body blocks as Markdown for glamour, which
+// prints backslash escapes as they are. Text is left unescaped, as the body
+// was before it had structure.
+func (a *Article) TerminalMarkdown() string {
+	return markdownRenderer{}.body(a)
+}
+
+type markdownRenderer struct {
+	// escape backslash-escapes Markdown syntax in body text.
+	escape bool
+}
+
+func (r markdownRenderer) body(a *Article) string {
+	if len(a.Blocks) == 0 {
+		// Content from before blocks were recorded is passed through as is.
+		return strings.TrimSpace(a.Content)
+	}
+	parts := make([]string, 0, len(a.Blocks))
+	for _, block := range a.Blocks {
+		if md := r.block(block); md != "" {
+			parts = append(parts, md)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (r markdownRenderer) block(block Block) string {
+	switch block.Kind {
+	case BlockHeading:
+		level := block.Level
+		if level < 2 {
+			level = 2
+		}
+		return strings.Repeat("#", level) + " " + r.spans(block.Spans)
+	case BlockQuote, BlockPullQuote:
+		return "> " + r.spans(block.Spans)
+	case BlockList:
+		lines := make([]string, 0, len(block.Items))
+		for i, item := range block.Items {
+			marker := "-"
+			if block.Ordered {
+				marker = fmt.Sprintf("%d.", i+1)
+			}
+			lines = append(lines, marker+" "+r.spans(item))
+		}
+		return strings.Join(lines, "\n")
+	case BlockFigure:
+		return r.figure(block)
+	case BlockTable:
+		return r.table(block)
+	default:
+		return r.lineStart(r.spans(block.Spans))
+	}
+}
+
+// figure renders the image followed by a line with its caption and credit.
+func (r markdownRenderer) figure(block Block) string {
+	var lines []string
+	if block.Src != "" {
+		alt := block.Alt
+		if alt == "" {
+			alt = SpansText(block.Spans)
+		}
+		lines = append(lines, fmt.Sprintf("![%s](%s)", r.text(alt), linkEscaper.Replace(block.Src)))
+	}
+	var caption []string
+	if len(block.Spans) > 0 {
+		caption = append(caption, "_"+r.spans(block.Spans)+"_")
+	}
+	if block.Credit != "" {
+		caption = append(caption, r.text(block.Credit))
+	}
+	if len(caption) > 0 {
+		lines = append(lines, strings.Join(caption, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r markdownRenderer) table(block Block) string {
+	columns := 0
+	for _, row := range block.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	rows := block.Rows
+	header := make([]string, columns)
+	if block.Header {
+		copy(header, rows[0])
+		rows = rows[1:]
+	}
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = strings.ReplaceAll(r.text(cells[i]), "|", `\|`)
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(header)
+	sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// spans renders spans as inline Markdown, keeping the whitespace at
+// the edges of a span outside its emphasis markers. Consecutive spans with
+// the same link become one Markdown link.
+func (r markdownRenderer) spans(spans []Span) string {
+	var sb strings.Builder
+	for i := 0; i < len(spans); {
+		link := spans[i].Link
+		j := i + 1
+		for link != "" && j < len(spans) && spans[j].Link == link {
+			j++
+		}
+		var text strings.Builder
+		for _, span := range spans[i:j] {
+			text.WriteString(r.emphasis(span))
+		}
+		if link == "" {
+			sb.WriteString(text.String())
+		} else {
+			lead, core, trail := splitSpace(text.String())
+			sb.WriteString(lead + "[" + core + "](" + linkEscaper.Replace(link) + ")" + trail)
+		}
+		i = j
+	}
+	return sb.String()
+}
+
+func (r markdownRenderer) emphasis(span Span) string {
+	lead, core, trail := splitSpace(r.text(span.Text))
+	if core == "" || (!span.Bold && !span.Italic) {
+		return lead + core + trail
+	}
+	open, close := "", ""
+	if span.Bold {
+		open, close = "**", "**"
+	}
+	if span.Italic {
+		open, close = open+"_", "_"+close
+	}
+	return lead + open + core + close + trail
+}
+
+// splitSpace splits text into its leading spaces, the rest and its trailing
+// spaces.
+func splitSpace(text string) (string, string, string) {
+	core := strings.Trim(text, " ")
+	if core == "" {
+		return text, "", ""
+	}
+	lead := text[:strings.Index(text, core)]
+	return lead, core, text[len(lead)+len(core):]
+}
+
+var linkEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// MarkdownLink returns a Markdown link to url with text escaped, so brackets
+// in a title do not end the link early.
+func MarkdownLink(text, url string) string {
+	return "[" + markdownEscaper.Replace(text) + "](" + linkEscaper.Replace(url) + ")"
+}
+
+func (r markdownRenderer) text(text string) string {
+	if !r.escape {
+		return text
+	}
+	return markdownEscaper.Replace(text)
+}
+
+// lineStart stops a paragraph that starts like a heading, quote or list item
+// from being read as one.
+func (r markdownRenderer) lineStart(text string) string {
+	if r.escape && text != "" && strings.ContainsRune("#>-+", rune(text[0])) {
+		return `\` + text
+	}
+	return text
+}
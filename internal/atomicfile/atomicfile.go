@@ -0,0 +1,66 @@
+// Package atomicfile writes files so that readers never see a partial write,
+// and serialises read-modify-write cycles between processes.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// TempPrefix starts the name of a file being written. Files left with it by
+// a crashed writer can be removed.
+const TempPrefix = ".tmp-"
+
+// Write writes data to a temp file in the same directory and renames it over
+// path, so readers see either the old contents or the new.
+func Write(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, TempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Lock takes an exclusive lock on path's lock file, path+".lock", for a
+// load-modify-save of path. The returned func releases it.
+func Lock(path string) (func(), error) {
+	return LockFile(path+".lock", true)
+}
+
+// LockFile takes a shared or exclusive advisory lock on the file at path,
+// creating it if needed. The returned func releases it.
+func LockFile(path string, exclusive bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := flock(file, exclusive); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("lock %s: %w", filepath.Base(path), err)
+	}
+	return func() {
+		_ = funlock(file)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package atomicfile
+
+import "os"
+
+// Advisory locking is only implemented on Unix. Elsewhere writes are still
+// atomic, but a purge may race a concurrent write.
+
+func flock(file *os.File, exclusive bool) error {
+	return nil
+}
+
+func funlock(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,161 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+const bookmarksFileName = "bookmarks.json"
+
+// Bookmark is a starred headline. It keeps the feed metadata so it can be
+// listed after the item drops out of the RSS feed.
+type Bookmark struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `json:"url"`
+	GUID        string    `json:"guid,omitempty"`
+	PubDate     string    `json:"pub_date,omitempty"`
+	Sections    []string  `json:"sections,omitempty"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+type bookmarksFile struct {
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+// New returns a bookmark for item, added now.
+func New(item rss.Item, sections []string) Bookmark {
+	return Bookmark{
+		Title:       item.CleanTitle(),
+		Description: item.CleanDescription(),
+		URL:         strings.TrimSpace(item.Link),
+		GUID:        strings.TrimSpace(item.GUID),
+		PubDate:     item.PubDate,
+		Sections:    sections,
+		AddedAt:     time.Now().UTC(),
+	}
+}
+
+// Item returns the bookmark as a feed item.
+func (b Bookmark) Item() rss.Item {
+	return rss.Item{
+		Title:       b.Title,
+		Description: b.Description,
+		Link:        b.URL,
+		GUID:        b.GUID,
+		PubDate:     b.PubDate,
+	}
+}
+
+func Path() string {
+	return filepath.Join(config.ConfigDir(), bookmarksFileName)
+}
+
+// List returns bookmarks, most recently added first.
+func List() ([]Bookmark, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var file bookmarksFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(file.Bookmarks, func(i, j int) bool {
+		return file.Bookmarks[i].AddedAt.After(file.Bookmarks[j].AddedAt)
+	})
+	return file.Bookmarks, nil
+}
+
+// Add stores b unless an item with the same URL or GUID is already bookmarked.
+// It reports whether the bookmark was added.
+func Add(b Bookmark) (bool, error) {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	list, err := List()
+	if err != nil {
+		return false, err
+	}
+	if index(list, b.Item()) >= 0 {
+		return false, nil
+	}
+	return true, save(append([]Bookmark{b}, list...))
+}
+
+// Remove deletes the bookmark matching item's URL or GUID and reports whether
+// one was found.
+func Remove(item rss.Item) (bool, error) {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	list, err := List()
+	if err != nil {
+		return false, err
+	}
+	i := index(list, item)
+	if i < 0 {
+		return false, nil
+	}
+	return true, save(append(list[:i], list[i+1:]...))
+}
+
+// Toggle bookmarks item, or removes it if already bookmarked. It reports
+// whether the item is bookmarked afterwards.
+func Toggle(item rss.Item, sections []string) (bool, error) {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	list, err := List()
+	if err != nil {
+		return false, err
+	}
+	if i := index(list, item); i >= 0 {
+		return false, save(append(list[:i], list[i+1:]...))
+	}
+	return true, save(append([]Bookmark{New(item, sections)}, list...))
+}
+
+func index(list []Bookmark, item rss.Item) int {
+	keys := item.Keys()
+	for i, b := range list {
+		for _, key := range b.Item().Keys() {
+			for _, want := range keys {
+				if key == want {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// save writes list. The caller holds the bookmarks lock.
+func save(list []Bookmark) error {
+	data, err := json.MarshalIndent(bookmarksFile{Bookmarks: list}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(Path(), data)
+}
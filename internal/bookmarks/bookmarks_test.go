@@ -0,0 +1,80 @@
+package bookmarks
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+func TestAddListRemove(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	first := rss.Item{Title: "First", Link: "https://example.com/first", GUID: "g1", PubDate: "Mon, 19 Jan 2026 10:00:00 +0000"}
+	second := rss.Item{Title: "Second", Link: "https://example.com/second"}
+
+	if added, err := Add(New(first, []string{"finance"})); err != nil || !added {
+		t.Fatalf("add first: added=%t err=%v", added, err)
+	}
+	if added, err := Add(New(second, nil)); err != nil || !added {
+		t.Fatalf("add second: added=%t err=%v", added, err)
+	}
+	if added, _ := Add(New(rss.Item{GUID: "g1"}, nil)); added {
+		t.Fatalf("expected duplicate GUID to be ignored")
+	}
+
+	list, err := List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(list))
+	}
+	if list[1].Item() != first || list[1].Sections[0] != "finance" {
+		t.Fatalf("expected item metadata to round-trip, got %#v", list[1])
+	}
+
+	removed, err := Remove(rss.Item{Link: "https://example.com/first"})
+	if err != nil || !removed {
+		t.Fatalf("remove: removed=%t err=%v", removed, err)
+	}
+	list, _ = List()
+	if len(list) != 1 || list[0].URL != second.Link {
+		t.Fatalf("expected only second bookmark left, got %#v", list)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	item := rss.Item{Title: "Item", Link: "https://example.com/item"}
+
+	if on, err := Toggle(item, nil); err != nil || !on {
+		t.Fatalf("expected toggle on, got %t %v", on, err)
+	}
+	if on, err := Toggle(item, nil); err != nil || on {
+		t.Fatalf("expected toggle off, got %t %v", on, err)
+	}
+	if list, _ := List(); len(list) != 0 {
+		t.Fatalf("expected no bookmarks, got %d", len(list))
+	}
+}
+
+func TestToggleIsAtomic(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	item := rss.Item{Title: "Item", Link: "https://example.com/item"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Toggle(item, nil); err != nil {
+				t.Errorf("toggle: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if list, _ := List(); len(list) != 0 {
+		t.Fatalf("expected an even number of toggles to leave no bookmark, got %d", len(list))
+	}
+}
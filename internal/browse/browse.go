@@ -2,6 +2,7 @@ package browse
 
 import (
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tmustier/economist-tui/internal/app"
@@ -10,9 +11,12 @@ import (
 )
 
 type Options struct {
-	Debug   bool
-	NoColor bool
-	Source  DataSource
+	Debug       bool
+	NoColor     bool
+	AllSections bool
+	Edition     bool
+	Issue       time.Time
+	Source      DataSource
 }
 
 func Run(section string, opts Options) error {
@@ -21,19 +25,39 @@ func Run(section string, opts Options) error {
 		source = rssSource{debug: opts.Debug}
 	}
 
-	sectionTitle, items, err := loadSection(source, section)
-	if err != nil {
-		return err
+	initial := app.ScreenBrowse
+	browseBuilder := func() tea.Model {
+		return NewLoadingModel(section, opts, source)
+	}
+	if opts.Edition {
+		initial = app.ScreenEdition
+	} else if opts.AllSections {
+		initial = app.ScreenAll
+	} else {
+		sectionTitle, items, err := loadSection(source, section)
+		if err != nil {
+			return err
+		}
+		browseBuilder = func() tea.Model {
+			return NewModel(section, items, sectionTitle, opts, source)
+		}
 	}
 
-	if _, ok := source.(rssSource); ok {
+	if _, ok := source.(rssSource); ok && !opts.AllSections && !opts.Edition {
 		go rss.PrefetchAll()
 	}
 
 	ui.InitTheme()
-	host, err := app.NewHost(app.ScreenBrowse, map[app.ScreenID]app.ScreenBuilder{
-		app.ScreenBrowse: func() tea.Model {
-			return NewModel(section, items, sectionTitle, opts, source)
+	host, err := app.NewHost(initial, map[app.ScreenID]app.ScreenBuilder{
+		app.ScreenBrowse: browseBuilder,
+		app.ScreenAll: func() tea.Model {
+			return NewAllSectionsModel(opts, source)
+		},
+		app.ScreenBookmarks: func() tea.Model {
+			return NewBookmarksModel(opts, source)
+		},
+		app.ScreenEdition: func() tea.Model {
+			return NewEditionModel(opts, source)
 		},
 	})
 	if err != nil {
@@ -44,6 +68,47 @@ func Run(section string, opts Options) error {
 	return err
 }
 
+func loadAllSections(source DataSource) ([]rss.TaggedItem, error) {
+	var items []rss.TaggedItem
+	if all, ok := source.(AllSectionsSource); ok {
+		var err error
+		items, err = all.AllSections()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var feeds []rss.SectionItems
+		for _, info := range rss.SectionList() {
+			_, sectionItems, err := source.Section(info.Primary)
+			if err != nil {
+				continue
+			}
+			feeds = append(feeds, rss.SectionItems{Section: info.Primary, Items: sectionItems})
+		}
+		items = rss.Merge(feeds)
+	}
+
+	if len(items) > allSectionsLimit {
+		items = items[:allSectionsLimit]
+	}
+	return items, nil
+}
+
+func loadEdition(source DataSource, issue time.Time) (rss.Edition, error) {
+	if editions, ok := source.(EditionSource); ok {
+		return editions.Edition(issue)
+	}
+	var feeds []rss.SectionItems
+	for _, path := range rss.EditionPaths() {
+		_, items, err := source.Section(path)
+		if err != nil {
+			continue
+		}
+		feeds = append(feeds, rss.SectionItems{Section: path, Items: items})
+	}
+	return rss.BuildEdition(feeds, issue), nil
+}
+
 func loadSection(source DataSource, section string) (string, []rss.Item, error) {
 	sectionTitle, items, err := source.Section(section)
 	if err != nil {
@@ -18,7 +18,13 @@ var browseHelpLineSpecs = []helpLineSpec{
 	},
 	{
 		Options: []string{
-			"↵ read • esc clear • q quit",
+			"↵ read • ^s star • ^b starred • ^e edition • ^f bodies • ^a all • ^r unread • esc • q quit",
+			"↵ read • ^s star • ^b starred • ^f bodies • ^a all • ^r unread • esc • q quit",
+			"↵ read • ^s star • ^b starred • ^f bodies • ^a all • esc • q quit",
+			"↵ read • ^f bodies • ^a all • ^r unread • esc clear • q quit",
+			"↵ read • ^f bodies • ^a all • esc clear • q quit",
+			"↵ read • ^f bodies • ^a all • esc • q quit",
+			"↵ read • ^f bodies • esc • q quit",
 			"↵ read • esc • q quit",
 			"↵ • esc • q",
 			"↵ • q",
@@ -2,20 +2,30 @@ package browse
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/app"
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/index"
 	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/search"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
+// openURL shows figure images outside the terminal; tests replace it.
+var openURL = browser.Open
+
 type viewMode int
 
 const (
@@ -30,6 +40,36 @@ type articleMsg struct {
 	fetchDuration time.Duration
 }
 
+type bodyIndexMsg struct {
+	index *index.Index
+	err   error
+}
+
+type allSectionsMsg struct {
+	items []rss.TaggedItem
+	err   error
+}
+
+type editionMsg struct {
+	edition rss.Edition
+	err     error
+}
+
+type bookmarksMsg struct {
+	list []bookmarks.Bookmark
+	err  error
+}
+
+// prefetchedMsg reports links the source's Prefetcher accepted.
+type prefetchedMsg struct {
+	urls []string
+}
+
+// availableMsg reports which links can be opened while offline.
+type availableMsg struct {
+	available map[string]bool
+}
+
 type sectionMsg struct {
 	section string
 	title   string
@@ -49,12 +89,36 @@ type Model struct {
 	sectionLoading      bool
 	sectionErr          error
 
+	allSections  bool
+	itemSections map[string][]string
+
+	edition bool
+	issue   time.Time
+
+	readState *readstate.State
+	hideRead  bool
+
+	bookmarksView bool
+	bookmarked    map[string]bool
+
+	// prefetched holds links the source's Prefetcher has accepted.
+	prefetched map[string]bool
+	// available holds which links can be opened while offline, and checked
+	// the links already sent to the source's Available.
+	available map[string]bool
+	checked   map[string]bool
+
 	cursor      int
 	browseStart int
 	width       int
 	height      int
 	searchQuery string
 
+	bodySearch   bool
+	bodyIndex    *index.Index
+	bodySnippets map[string]string
+	bodyErr      error
+
 	mode         viewMode
 	loading      bool
 	loadingItem  *rss.Item
@@ -65,6 +129,21 @@ type Model struct {
 	articleErr   error
 	scroll       int
 	twoColumn    bool
+	statusMsg    string
+
+	// articleURL is the link the shown article was opened from. Reading
+	// positions are kept as paragraph indices; paragraphRows maps them to
+	// body rows for the current layout, which starts at bodyOffset.
+	articleURL    string
+	paragraphRows []int
+	bodyOffset    int
+
+	// selectedLink is the number of the body link o follows, 0 for none.
+	// linkHistory holds the articles left by following links, and
+	// markOnLoad the followed link to mark read once its article loads.
+	selectedLink int
+	linkHistory  []rss.Item
+	markOnLoad   string
 
 	fetchDuration  time.Duration
 	baseDuration   time.Duration
@@ -81,6 +160,15 @@ func NewModel(section string, items []rss.Item, sectionTitle string, opts Option
 	w, h := ui.TermSize(int(os.Stdout.Fd()))
 	sections := rss.SectionList()
 	sectionIndex, sections := resolveSectionIndex(section, sections)
+	var readState *readstate.State
+	if tracker, ok := source.(ReadTracker); ok {
+		readState, _ = tracker.ReadState()
+	}
+	var bookmarked map[string]bool
+	if marker, ok := source.(Bookmarker); ok {
+		list, _ := marker.Bookmarks()
+		bookmarked = bookmarkSet(list)
+	}
 	return Model{
 		allItems:            items,
 		filteredItems:       items,
@@ -93,10 +181,81 @@ func NewModel(section string, items []rss.Item, sectionTitle string, opts Option
 		source:              source,
 		opts:                opts,
 		pendingSectionIndex: -1,
+		readState:           readState,
+		bookmarked:          bookmarked,
+		prefetched:          make(map[string]bool),
+		available:           make(map[string]bool),
+		checked:             make(map[string]bool),
+	}
+}
+
+// NewLoadingModel returns a section model that fetches its items on Init.
+func NewLoadingModel(section string, opts Options, source DataSource) Model {
+	m := NewModel(section, nil, section, opts, source)
+	m.pendingSection = m.sections[m.sectionIndex].Primary
+	m.pendingSectionIndex = m.sectionIndex
+	m.sectionLoading = true
+	return m
+}
+
+// NewAllSectionsModel returns a river-of-news model that interleaves every
+// section by date. Items are fetched on Init.
+func NewAllSectionsModel(opts Options, source DataSource) Model {
+	m := NewModel("", nil, allSectionsTitle, opts, source)
+	m.allSections = true
+	m.sections = nil
+	m.sectionIndex = 0
+	m.pendingSection = strings.ToLower(allSectionsTitle)
+	m.sectionLoading = true
+	return m
+}
+
+// NewEditionModel returns a model listing the weekly issue dated opts.Issue,
+// or the latest one, in print order. Items are fetched on Init.
+func NewEditionModel(opts Options, source DataSource) Model {
+	m := NewModel("", nil, editionTitle, opts, source)
+	m.edition = true
+	m.issue = opts.Issue
+	m.sections = nil
+	m.sectionIndex = 0
+	m.pendingSection = strings.ToLower(editionTitle)
+	m.sectionLoading = true
+	return m
+}
+
+// NewBookmarksModel returns a model listing bookmarked headlines. Bookmarks
+// are loaded on Init.
+func NewBookmarksModel(opts Options, source DataSource) Model {
+	m := NewModel("", nil, bookmarksTitle, opts, source)
+	m.bookmarksView = true
+	m.sections = nil
+	m.sectionIndex = 0
+	if _, ok := m.source.(Bookmarker); ok {
+		m.pendingSection = strings.ToLower(bookmarksTitle)
+		m.sectionLoading = true
 	}
+	return m
 }
 
+// Init fetches pending items and refreshes bookmark stars, which another
+// screen may have changed.
 func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.fetchPendingCmd(), m.fetchBookmarksCmd(), m.prefetchCmd(), m.availableCmd())
+}
+
+func (m Model) fetchPendingCmd() tea.Cmd {
+	if !m.sectionLoading || len(m.allItems) > 0 || m.bookmarksView {
+		return nil
+	}
+	if m.allSections {
+		return m.fetchAllSectionsCmd()
+	}
+	if m.edition {
+		return m.fetchEditionCmd()
+	}
+	if m.pendingSection != "" {
+		return m.fetchSectionCmd(m.pendingSection)
+	}
 	return nil
 }
 
@@ -127,30 +286,58 @@ func resolveSectionIndex(section string, sections []rss.SectionInfo) (int, []rss
 }
 
 func (m *Model) applySearch() {
+	items := m.baseItems()
 	query := strings.TrimSpace(m.searchQuery)
 	if query == "" {
-		m.filteredItems = m.allItems
+		m.filteredItems = items
+		m.bodySnippets = nil
+		if m.cursor >= len(m.filteredItems) {
+			m.cursor = ui.Max(0, len(m.filteredItems)-1)
+		}
 		m.ensureBrowseWindow()
 		return
 	}
 
 	if isDigits(query) {
-		m.filteredItems = m.allItems
+		m.filteredItems = items
 		idx, err := strconv.Atoi(query)
-		if err == nil && idx > 0 && idx <= len(m.allItems) {
+		if err == nil && idx > 0 && idx <= len(items) {
 			m.cursor = idx - 1
 		}
 		m.ensureBrowseWindow()
 		return
 	}
 
-	var filtered []rss.Item
-	for _, item := range m.allItems {
-		text := item.CleanTitle() + " " + item.CleanDescription()
-		if search.Match(text, query) {
-			filtered = append(filtered, item)
+	q := search.Parse(query)
+	m.bodySnippets = nil
+	if m.bodySearch && m.bodyIndex != nil {
+		m.bodySnippets = bodyMatches(m.bodyIndex, q)
+	}
+
+	type scoredItem struct {
+		item  rss.Item
+		score float64
+	}
+	var matches []scoredItem
+	for _, item := range items {
+		doc := item.SearchDocument(m.itemSectionPath(item))
+		score, ok := q.Score(doc)
+		if !ok {
+			_, bodyHit := m.bodySnippets[item.Link]
+			ok = bodyHit && q.Filter(doc)
+		}
+		if ok {
+			matches = append(matches, scoredItem{item: item, score: score})
 		}
 	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	filtered := make([]rss.Item, 0, len(matches))
+	for _, match := range matches {
+		filtered = append(filtered, match.item)
+	}
 	m.filteredItems = filtered
 
 	// Reset cursor if out of bounds
@@ -160,6 +347,73 @@ func (m *Model) applySearch() {
 	m.ensureBrowseWindow()
 }
 
+// bodyMatches returns snippets, keyed by URL, for indexed articles whose body
+// matches q.
+func bodyMatches(ix *index.Index, q search.Query) map[string]string {
+	results := ix.Match(q, 0)
+	if len(results) == 0 {
+		return nil
+	}
+	snippets := make(map[string]string, len(results))
+	for _, result := range results {
+		snippets[result.URL] = result.Snippet
+	}
+	return snippets
+}
+
+// baseItems returns the items search runs over: all items, or only unread
+// ones when read items are hidden.
+func (m Model) baseItems() []rss.Item {
+	if !m.hideRead || m.readState == nil {
+		return m.allItems
+	}
+	items := make([]rss.Item, 0, len(m.allItems))
+	for _, item := range m.allItems {
+		if !m.readState.IsRead(item.Keys()...) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// markRead records item as read. Read state is advisory, so failures to
+// persist it are ignored.
+func (m *Model) markRead(item rss.Item) {
+	tracker, ok := m.source.(ReadTracker)
+	keys := item.Keys()
+	if !ok || len(keys) == 0 {
+		return
+	}
+	if m.readState == nil {
+		m.readState = readstate.New()
+	}
+	m.readState.Mark(keys...)
+	_ = tracker.MarkRead(keys...)
+}
+
+// itemSectionPath returns the section paths used for section: search filters.
+func (m Model) itemSectionPath(item rss.Item) string {
+	if m.itemSections == nil {
+		return m.currentSectionPath()
+	}
+	tags := m.itemSections[item.Link]
+	paths := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if path, ok := rss.Sections[strings.ToLower(tag)]; ok {
+			tag = path
+		}
+		paths = append(paths, tag)
+	}
+	return strings.Join(paths, " ")
+}
+
+func (m Model) currentSectionPath() string {
+	if m.sectionIndex >= 0 && m.sectionIndex < len(m.sections) {
+		return m.sections[m.sectionIndex].Path
+	}
+	return ""
+}
+
 func isDigits(input string) bool {
 	for _, r := range input {
 		if !unicode.IsDigit(r) {
@@ -192,8 +446,209 @@ func (m Model) fetchSectionCmd(section string) tea.Cmd {
 	}
 }
 
+func (m Model) fetchAllSectionsCmd() tea.Cmd {
+	source := m.source
+	if source == nil {
+		source = rssSource{debug: m.opts.Debug}
+	}
+	return func() tea.Msg {
+		items, err := loadAllSections(source)
+		return allSectionsMsg{items: items, err: err}
+	}
+}
+
+func (m Model) fetchEditionCmd() tea.Cmd {
+	source, issue := m.source, m.issue
+	return func() tea.Msg {
+		edition, err := loadEdition(source, issue)
+		return editionMsg{edition: edition, err: err}
+	}
+}
+
+// prefetchCmd sends the first visible headlines and the one under the
+// cursor to the source's Prefetcher, skipping links it has already accepted.
+func (m Model) prefetchCmd() tea.Cmd {
+	prefetcher, ok := m.source.(Prefetcher)
+	if !ok || m.prefetched == nil || len(m.filteredItems) == 0 {
+		return nil
+	}
+
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(item rss.Item) {
+		if item.Link != "" && !m.prefetched[item.Link] && !seen[item.Link] {
+			seen[item.Link] = true
+			urls = append(urls, item.Link)
+		}
+	}
+	if m.cursor >= 0 && m.cursor < len(m.filteredItems) {
+		add(m.filteredItems[m.cursor])
+	}
+	end := m.browseStart + prefetchCount
+	if end > len(m.filteredItems) {
+		end = len(m.filteredItems)
+	}
+	for i := m.browseStart; i < end; i++ {
+		add(m.filteredItems[i])
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := prefetcher.Prefetch(urls); err != nil {
+			return nil
+		}
+		return prefetchedMsg{urls: urls}
+	}
+}
+
+// availableCmd looks up, while offline, which of the listed links not checked
+// yet can be opened, so rendering never reads the cache or library.
+func (m Model) availableCmd() tea.Cmd {
+	source, ok := m.source.(OfflineSource)
+	if !ok || m.checked == nil || !source.Offline() {
+		return nil
+	}
+	var urls []string
+	for _, item := range m.allItems {
+		if item.Link != "" && !m.checked[item.Link] {
+			m.checked[item.Link] = true
+			urls = append(urls, item.Link)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return availableMsg{available: source.Available(urls)}
+	}
+}
+
+func (m Model) fetchBookmarksCmd() tea.Cmd {
+	marker, ok := m.source.(Bookmarker)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		list, err := marker.Bookmarks()
+		return bookmarksMsg{list: list, err: err}
+	}
+}
+
+func (m Model) fetchBodyIndexCmd() tea.Cmd {
+	indexer, ok := m.source.(BodyIndexer)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		ix, err := indexer.BodyIndex()
+		return bodyIndexMsg{index: ix, err: err}
+	}
+}
+
+// Update handles msg, then asks the source to prefetch any newly visible
+// headlines, or offline, which newly listed ones can be opened.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if updated, ok := next.(Model); ok && updated.mode == modeBrowse {
+		return updated, tea.Batch(cmd, updated.prefetchCmd(), updated.availableCmd())
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case bookmarksMsg:
+		if msg.err == nil {
+			m.bookmarked = bookmarkSet(msg.list)
+		}
+		if !m.bookmarksView {
+			return m, nil
+		}
+		m.sectionLoading = false
+		m.pendingSection = ""
+		if msg.err != nil {
+			m.sectionErr = msg.err
+			return m, nil
+		}
+		m.sectionErr = nil
+		items := make([]rss.Item, len(msg.list))
+		m.itemSections = make(map[string][]string, len(msg.list))
+		for i, b := range msg.list {
+			items[i] = b.Item()
+			m.itemSections[b.URL] = b.Sections
+		}
+		m.allItems = items
+		m.filteredItems = items
+		m.cursor = 0
+		m.browseStart = 0
+		m.applySearch()
+		return m, nil
+	case allSectionsMsg:
+		if !m.allSections {
+			return m, nil
+		}
+		m.sectionLoading = false
+		m.pendingSection = ""
+		if msg.err != nil {
+			m.sectionErr = msg.err
+			return m, nil
+		}
+		m.sectionErr = nil
+		items := make([]rss.Item, len(msg.items))
+		m.itemSections = make(map[string][]string, len(msg.items))
+		for i, item := range msg.items {
+			items[i] = item.Item
+			m.itemSections[item.Link] = item.Sections
+		}
+		m.allItems = items
+		m.filteredItems = items
+		m.cursor = 0
+		m.browseStart = 0
+		m.applySearch()
+		return m, nil
+	case editionMsg:
+		if !m.edition {
+			return m, nil
+		}
+		m.sectionLoading = false
+		m.pendingSection = ""
+		if msg.err != nil {
+			m.sectionErr = msg.err
+			return m, nil
+		}
+		m.sectionErr = nil
+		m.sectionTitle = msg.edition.Title()
+		tagged := msg.edition.Items()
+		items := make([]rss.Item, len(tagged))
+		m.itemSections = make(map[string][]string, len(tagged))
+		for i, item := range tagged {
+			items[i] = item.Item
+			m.itemSections[item.Link] = item.Sections
+		}
+		m.allItems = items
+		m.filteredItems = items
+		m.cursor = 0
+		m.browseStart = 0
+		m.applySearch()
+		return m, nil
+	case prefetchedMsg:
+		for _, url := range msg.urls {
+			m.prefetched[url] = true
+		}
+		return m, nil
+	case availableMsg:
+		for url, available := range msg.available {
+			m.available[url] = available
+		}
+		return m, nil
+	case bodyIndexMsg:
+		m.bodyErr = msg.err
+		if msg.err == nil {
+			m.bodyIndex = msg.index
+		}
+		m.applySearch()
+		return m, nil
 	case sectionMsg:
 		if msg.section != m.pendingSection {
 			return m, nil
@@ -224,6 +679,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.pendingURL = ""
 		m.scroll = 0
+		m.statusMsg = ""
 		m.fetchDuration = msg.fetchDuration
 		if msg.err != nil {
 			m.articleErr = msg.err
@@ -234,8 +690,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.articleErr = nil
 		m.article = msg.article
+		m.articleURL = msg.url
+		if m.markOnLoad == msg.url {
+			m.markRead(rss.Item{Title: msg.article.Title, Link: msg.url})
+			m.markOnLoad = ""
+		}
+		m.selectedLink = 0
 		m.articleBase = ""
+		m.paragraphRows = nil
 		m.refreshArticleLines()
+		if pos, ok := m.readState.Position(msg.url); ok && pos.Percent < 100 {
+			m.scrollToParagraph(pos.Paragraph)
+		}
 		return m, nil
 	case tea.KeyMsg:
 		if m.mode == modeArticle {
@@ -256,6 +722,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
 	switch msg.String() {
 	case "ctrl+c", "ctrl+d":
 		return m, tea.Quit
@@ -263,6 +730,20 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.searchQuery == "" {
 			return m, tea.Quit
 		}
+	case "ctrl+f":
+		return m.toggleBodySearch()
+	case "ctrl+a":
+		return m.toggleAllSections()
+	case "ctrl+s":
+		return m.toggleBookmark()
+	case "ctrl+b":
+		return m.toggleBookmarksScreen()
+	case "ctrl+e":
+		return m.toggleEdition()
+	case "ctrl+r":
+		m.hideRead = !m.hideRead
+		m.applySearch()
+		return m, nil
 	}
 
 	switch msg.Type {
@@ -274,8 +755,14 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 	case tea.KeyTab:
+		if m.edition {
+			return m.jumpEditionSection(1)
+		}
 		return m.queueSectionChange(1)
 	case tea.KeyShiftTab:
+		if m.edition {
+			return m.jumpEditionSection(-1)
+		}
 		return m.queueSectionChange(-1)
 	case tea.KeyBackspace:
 		if len(m.searchQuery) > 0 {
@@ -285,6 +772,7 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEnter:
 		if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
 			item := m.filteredItems[m.cursor]
+			m.markRead(item)
 			m.mode = modeArticle
 			m.loading = true
 			m.loadingItem = &item
@@ -358,6 +846,151 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleAllSections switches between the single-section and all-sections screens.
+func (m Model) toggleAllSections() (tea.Model, tea.Cmd) {
+	target := app.ScreenAll
+	if m.allSections {
+		target = app.ScreenBrowse
+	}
+	return m, func() tea.Msg {
+		return app.SwitchScreenMsg{ID: target}
+	}
+}
+
+// toggleEdition switches between the weekly edition and section browsing.
+func (m Model) toggleEdition() (tea.Model, tea.Cmd) {
+	target := app.ScreenEdition
+	if m.edition {
+		target = app.ScreenBrowse
+	}
+	return m, func() tea.Msg {
+		return app.SwitchScreenMsg{ID: target}
+	}
+}
+
+// jumpEditionSection moves the cursor to the first item of the next or
+// previous section of the edition.
+func (m Model) jumpEditionSection(delta int) (tea.Model, tea.Cmd) {
+	items := m.filteredItems
+	if len(items) == 0 {
+		return m, nil
+	}
+	section := func(i int) string {
+		if tags := m.itemSections[items[i].Link]; len(tags) > 0 {
+			return tags[0]
+		}
+		return ""
+	}
+
+	cursor := m.cursor
+	if delta > 0 {
+		for cursor < len(items) && section(cursor) == section(m.cursor) {
+			cursor++
+		}
+		if cursor == len(items) {
+			cursor = 0
+		}
+	} else {
+		for cursor > 0 && section(cursor-1) == section(m.cursor) {
+			cursor--
+		}
+		if cursor == m.cursor {
+			if cursor == 0 {
+				cursor = len(items)
+			}
+			current := section(cursor - 1)
+			for cursor > 0 && section(cursor-1) == current {
+				cursor--
+			}
+		}
+	}
+	m.cursor = cursor
+	m.ensureBrowseWindow()
+	return m, nil
+}
+
+// toggleBookmarksScreen opens the bookmarks screen, rebuilt so it shows
+// bookmarks added since it was last open, or returns to browsing.
+func (m Model) toggleBookmarksScreen() (tea.Model, tea.Cmd) {
+	msg := app.SwitchScreenMsg{ID: app.ScreenBookmarks, Reset: true}
+	if m.bookmarksView {
+		msg = app.SwitchScreenMsg{ID: app.ScreenBrowse}
+	}
+	return m, func() tea.Msg {
+		return msg
+	}
+}
+
+func (m Model) toggleBookmark() (tea.Model, tea.Cmd) {
+	marker, ok := m.source.(Bookmarker)
+	if !ok || m.cursor >= len(m.filteredItems) {
+		return m, nil
+	}
+	item := m.filteredItems[m.cursor]
+	sections := m.itemSections[item.Link]
+	if sections == nil && m.sectionIndex < len(m.sections) {
+		sections = []string{m.sections[m.sectionIndex].Primary}
+	}
+
+	on, err := marker.ToggleBookmark(item, sections)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("bookmark failed: %v", err)
+		return m, nil
+	}
+	bookmarked := make(map[string]bool, len(m.bookmarked)+1)
+	for key := range m.bookmarked {
+		bookmarked[key] = true
+	}
+	for _, key := range item.Keys() {
+		if on {
+			bookmarked[key] = true
+		} else {
+			delete(bookmarked, key)
+		}
+	}
+	m.bookmarked = bookmarked
+	m.statusMsg = "bookmark removed"
+	if on {
+		m.statusMsg = "bookmarked"
+	}
+	return m, nil
+}
+
+func (m Model) isBookmarked(item rss.Item) bool {
+	for _, key := range item.Keys() {
+		if m.bookmarked[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func bookmarkSet(list []bookmarks.Bookmark) map[string]bool {
+	set := make(map[string]bool, len(list)*2)
+	for _, b := range list {
+		for _, key := range b.Item().Keys() {
+			set[key] = true
+		}
+	}
+	return set
+}
+
+// toggleBodySearch switches the search bar between headlines only and
+// headlines plus the full text of fetched articles.
+func (m Model) toggleBodySearch() (tea.Model, tea.Cmd) {
+	if _, ok := m.source.(BodyIndexer); !ok {
+		return m, nil
+	}
+	m.bodySearch = !m.bodySearch
+	m.bodyErr = nil
+	if m.bodySearch {
+		// Refresh on every toggle so articles read since the last search are included.
+		return m, m.fetchBodyIndexCmd()
+	}
+	m.applySearch()
+	return m, nil
+}
+
 func (m Model) pageBrowse(delta int) (tea.Model, tea.Cmd) {
 	itemCount := len(m.filteredItems)
 	if itemCount == 0 {
@@ -397,25 +1030,48 @@ func (m Model) pageBrowse(delta int) (tea.Model, tea.Cmd) {
 func (m Model) updateArticle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c", "ctrl+d", "q":
+		m.rememberPosition()
 		return m, tea.Quit
 	case "b", "enter":
+		m.rememberPosition()
 		m.mode = modeBrowse
 		m.loading = false
 		m.loadingItem = nil
 		m.pendingURL = ""
+		m.linkHistory = nil
+		m.markOnLoad = ""
 		return m, nil
+	case "l":
+		return m.selectLink(1), nil
+	case "L":
+		return m.selectLink(-1), nil
+	case "o":
+		return m.followLink()
 	case "c":
 		m.twoColumn = !m.twoColumn
 		m.refreshArticleLines()
 		return m, nil
+	case "s":
+		return m.saveArticle()
 	}
 
 	switch msg.Type {
 	case tea.KeyEsc:
+		m.rememberPosition()
 		m.mode = modeBrowse
 		m.loading = false
 		m.loadingItem = nil
 		m.pendingURL = ""
+		m.linkHistory = nil
+		m.markOnLoad = ""
+	case tea.KeyBackspace:
+		if n := len(m.linkHistory); n > 0 {
+			m.rememberPosition()
+			previous := m.linkHistory[n-1]
+			m.linkHistory = m.linkHistory[:n-1]
+			m.markOnLoad = ""
+			return m.openLink(previous)
+		}
 	case tea.KeyTab:
 		return m.navigateArticle(1)
 	case tea.KeyShiftTab:
@@ -438,6 +1094,125 @@ func (m Model) updateArticle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// selectLink moves the link selection by delta, wrapping around, and shows
+// the selected link in the status line.
+func (m Model) selectLink(delta int) Model {
+	if m.article == nil {
+		return m
+	}
+	links := m.article.Links()
+	if len(links) == 0 {
+		m.statusMsg = "no links in this article"
+		return m
+	}
+	m.selectedLink += delta
+	if m.selectedLink < 1 {
+		m.selectedLink = len(links)
+	} else if m.selectedLink > len(links) {
+		m.selectedLink = 1
+	}
+	link := links[m.selectedLink-1]
+	action := "o open"
+	if m.isFigureLink(link.URL) {
+		action = "o open image"
+	} else if !isArticleLink(link.URL) {
+		action = link.URL
+	}
+	m.statusMsg = fmt.Sprintf("[%d/%d] %s · %s", m.selectedLink, len(links), ui.Truncate(link.Text, 40), action)
+	return m
+}
+
+// followLink opens the selected link in the reader when it is an Economist
+// article, or in the system viewer when it is a figure's image. Backspace
+// returns to the article it was followed from.
+func (m Model) followLink() (tea.Model, tea.Cmd) {
+	if m.article == nil || m.selectedLink == 0 {
+		m.statusMsg = "press l to pick a link"
+		return m, nil
+	}
+	links := m.article.Links()
+	if m.selectedLink > len(links) {
+		return m, nil
+	}
+	link := links[m.selectedLink-1]
+	if m.isFigureLink(link.URL) {
+		if err := openURL(link.URL); err != nil {
+			m.statusMsg = "could not open image: " + err.Error()
+		} else {
+			m.statusMsg = "opened " + link.URL
+		}
+		return m, nil
+	}
+	if !isArticleLink(link.URL) {
+		m.statusMsg = "not an Economist article: " + link.URL
+		return m, nil
+	}
+	m.rememberPosition()
+	current := rss.Item{Title: m.article.Title, Link: m.articleURL}
+	if m.loadingItem != nil && m.loadingItem.Link == m.articleURL {
+		current = *m.loadingItem
+	}
+	m.linkHistory = append(m.linkHistory, current)
+	m.markOnLoad = link.URL
+	return m.openLink(rss.Item{Title: link.Text, Link: link.URL})
+}
+
+func (m Model) openLink(item rss.Item) (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.loadingItem = &item
+	m.pendingURL = item.Link
+	m.articleErr = nil
+	m.article = nil
+	m.articleBase = ""
+	m.articleLines = nil
+	m.paragraphRows = nil
+	m.scroll = 0
+	m.selectedLink = 0
+	m.statusMsg = ""
+	return m, m.fetchArticleCmd(item.Link)
+}
+
+// isFigureLink reports whether link is the image of a figure in the open
+// article.
+func (m Model) isFigureLink(link string) bool {
+	if m.article == nil {
+		return false
+	}
+	for _, figure := range m.article.Figures() {
+		if figure.Src == link {
+			return true
+		}
+	}
+	return false
+}
+
+// isArticleLink reports whether link points at an article on economist.com
+// rather than a section page or another site.
+func isArticleLink(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "economist.com" {
+		return false
+	}
+	return strings.Count(strings.Trim(u.Path, "/"), "/") >= 2
+}
+
+func (m Model) saveArticle() (tea.Model, tea.Cmd) {
+	saver, ok := m.source.(ArticleSaver)
+	if !ok || m.article == nil {
+		return m, nil
+	}
+	if err := saver.SaveArticle(m.article); err != nil {
+		m.statusMsg = fmt.Sprintf("save failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = "saved to library"
+	return m, nil
+}
+
 func (m Model) queueSectionChange(delta int) (tea.Model, tea.Cmd) {
 	if len(m.sections) == 0 {
 		return m, nil
@@ -472,6 +1247,9 @@ func (m Model) navigateArticle(delta int) (tea.Model, tea.Cmd) {
 	if len(m.filteredItems) == 0 {
 		return m, nil
 	}
+	m.rememberPosition()
+	m.linkHistory = nil
+	m.markOnLoad = ""
 
 	// Calculate new cursor position with wrapping
 	newCursor := m.cursor + delta
@@ -487,6 +1265,7 @@ func (m Model) navigateArticle(delta int) (tea.Model, tea.Cmd) {
 
 	// Fetch the new article
 	item := m.filteredItems[m.cursor]
+	m.markRead(item)
 	m.loading = true
 	m.loadingItem = &item
 	m.pendingURL = item.Link
@@ -508,7 +1287,7 @@ func (m *Model) refreshArticleLines() {
 
 	if m.articleBase == "" {
 		baseStart := time.Now()
-		base, err := ui.RenderArticleBodyBase(ui.ArticleBodyMarkdown(m.article), opts)
+		base, err := ui.RenderArticleBody(m.article, opts)
 		m.baseDuration = time.Since(baseStart)
 		if err != nil {
 			m.articleErr = err
@@ -537,8 +1316,18 @@ func (m *Model) refreshArticleLines() {
 		footer = ui.IndentBlock(footer, indent)
 	}
 
+	anchor := -1
+	if m.paragraphRows != nil {
+		anchor = m.currentParagraph()
+	}
+	m.paragraphRows = ui.ParagraphRows(m.articleBase, layout)
+	m.bodyOffset = strings.Count(header, "\n")
+
 	m.articleErr = nil
 	m.articleLines = strings.Split(strings.TrimRight(header+body+footer, "\n"), "\n")
+	if anchor > 0 {
+		m.scrollToParagraph(anchor)
+	}
 	m.clampArticleScroll()
 }
 
@@ -555,12 +1344,13 @@ func (m Model) articleRenderOptions() ui.ArticleRenderOptions {
 	}
 
 	return ui.ArticleRenderOptions{
-		NoColor:   m.opts.NoColor,
-		PlainBody: true,
-		WrapWidth: wrapWidth,
-		TermWidth: termWidth,
-		Center:    center,
-		TwoColumn: m.twoColumn,
+		NoColor:    m.opts.NoColor,
+		PlainBody:  true,
+		WrapWidth:  wrapWidth,
+		TermWidth:  termWidth,
+		Center:     center,
+		TwoColumn:  m.twoColumn,
+		Hyperlinks: ui.SupportsHyperlinks(),
 	}
 }
 
@@ -569,6 +1359,54 @@ func (m Model) pageSize(itemCount int) int {
 	return layout.maxVisible
 }
 
+// currentParagraph returns the paragraph at the top of the article view.
+func (m Model) currentParagraph() int {
+	row := m.scroll - m.bodyOffset
+	paragraph := 0
+	for i, start := range m.paragraphRows {
+		if i > 0 && start < m.paragraphRows[i-1] {
+			break // later columns
+		}
+		if start > row {
+			break
+		}
+		paragraph = i
+	}
+	return paragraph
+}
+
+func (m *Model) scrollToParagraph(paragraph int) {
+	if paragraph <= 0 || paragraph >= len(m.paragraphRows) {
+		return
+	}
+	m.scroll = m.bodyOffset + m.paragraphRows[paragraph]
+	m.clampArticleScroll()
+}
+
+// readPercent returns how much of the article has been on screen.
+func (m Model) readPercent() int {
+	if len(m.articleLines) == 0 || m.maxArticleScroll() == 0 {
+		return 100
+	}
+	seen := m.scroll + m.articleViewHeight()
+	return ui.Clamp(seen*100/len(m.articleLines), 0, 100)
+}
+
+// rememberPosition saves where reading stopped in the open article. Like
+// read state, positions are advisory and failures are ignored.
+func (m *Model) rememberPosition() {
+	tracker, ok := m.source.(ReadTracker)
+	if !ok || m.article == nil || m.articleURL == "" || m.loading {
+		return
+	}
+	pos := readstate.Position{Paragraph: m.currentParagraph(), Percent: m.readPercent()}
+	if m.readState == nil {
+		m.readState = readstate.New()
+	}
+	m.readState.SetPosition(m.articleURL, pos)
+	_ = tracker.SavePosition(m.articleURL, pos)
+}
+
 func (m Model) articleViewHeight() int {
 	spec := articleLayoutSpec(m.opts.Debug)
 	return spec.VisibleLines(m.height)
@@ -638,7 +1476,7 @@ func (m Model) maxVisibleItems(itemCount, visibleLines, titleLines, subtitleLine
 	used := 0
 	count := 0
 	for i := start; i < len(items); i++ {
-		height := browseItemHeight(items[i], titleWidth, titleLines, subtitleLines)
+		height := browseItemHeight(m.listItem(items[i], false), titleWidth, titleLines, subtitleLines)
 		if count > 0 && used+height > visibleLines {
 			break
 		}
@@ -674,15 +1512,78 @@ func (m Model) browseTitleWidth() int {
 	return titleWidth
 }
 
-func browseItemHeight(item rss.Item, titleWidth, titleLines, subtitleLines int) int {
+// listItem converts a feed item into a list row, substituting the matching
+// body paragraph for the description when it was found by body search.
+func (m Model) listItem(item rss.Item, compactDate bool) ui.ListItem {
+	date := item.FormattedDate()
+	if compactDate {
+		date = item.CompactDate()
+	}
+	subtitle := item.CleanDescription()
+	if snippet, ok := m.bodySnippets[item.Link]; ok && snippet != "" {
+		subtitle = snippet
+	}
+	badge := ""
+	if tags := m.itemSections[item.Link]; len(tags) > 0 {
+		label := strings.Join(tags, " · ")
+		if m.edition {
+			label = rss.SectionTitle(tags[0])
+		}
+		badge = ui.SectionBadge(label, ui.NewStyles(ui.CurrentTheme(), m.opts.NoColor))
+	}
+	detail := ""
+	if pos, ok := m.readState.Position(item.Link); ok && pos.Percent > 0 {
+		detail = fmt.Sprintf("%d%% read", pos.Percent)
+		if compactDate {
+			detail = fmt.Sprintf("%d%%", pos.Percent)
+		}
+	}
+	if !m.availableOffline(item.Link) {
+		detail = unavailableDetail
+		if compactDate {
+			detail = unavailableDetailCompact
+		}
+	}
 	title := item.CleanTitle()
-	titleLineCount := len(ui.LimitLines(ui.WrapLines(title, titleWidth), titleLines, titleWidth))
+	if m.isBookmarked(item) {
+		title = bookmarkMarker + title
+	}
+	return ui.ListItem{
+		Badge:    badge,
+		Title:    title,
+		Subtitle: subtitle,
+		Right:    date,
+		Detail:   detail,
+		Read:     m.readState.IsRead(item.Keys()...),
+	}
+}
+
+func (m Model) offline() bool {
+	source, ok := m.source.(OfflineSource)
+	return ok && source.Offline()
+}
+
+// availableOffline reports whether link can be opened. It is always true
+// while online, and for links availableCmd has not answered for yet.
+func (m Model) availableOffline(link string) bool {
+	if !m.offline() {
+		return true
+	}
+	available, ok := m.available[link]
+	return available || !ok
+}
+
+func browseItemHeight(item ui.ListItem, titleWidth, titleLines, subtitleLines int) int {
+	badgeLines := 0
+	if item.Badge != "" {
+		badgeLines = 1
+	}
+	titleLineCount := len(ui.LimitLines(ui.WrapLines(item.Title, titleWidth), titleLines, titleWidth))
 	if titleLineCount == 0 {
 		titleLineCount = 1
 	}
-	subtitle := item.CleanDescription()
-	subtitleLineCount := len(ui.LimitLines(ui.WrapLines(subtitle, titleWidth), subtitleLines, titleWidth))
-	return titleLineCount + subtitleLineCount + browseItemGapLines
+	subtitleLineCount := len(ui.LimitLines(ui.WrapLines(item.Subtitle, titleWidth), subtitleLines, titleWidth))
+	return badgeLines + titleLineCount + subtitleLineCount + browseItemGapLines
 }
 
 func resolveBrowseItemLines(visibleLines int) (int, int) {
@@ -1,9 +1,18 @@
 package browse
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/demo"
+	"github.com/tmustier/economist-tui/internal/index"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
 )
 
 func TestResolveSectionIndexUsesPrimaryAlias(t *testing.T) {
@@ -47,3 +56,424 @@ func TestQueueSectionChangeUsesPendingIndex(t *testing.T) {
 		t.Fatalf("expected sectionLoading to be true")
 	}
 }
+
+func TestApplySearchMatchesBodies(t *testing.T) {
+	items := []rss.Item{
+		{Title: "Interest rates", Description: "Central banks", Link: "https://example.com/rates"},
+		{Title: "Chips", Description: "Industrial policy", Link: "https://example.com/chips"},
+	}
+	m := Model{
+		allItems:      items,
+		filteredItems: items,
+		bodySearch:    true,
+		bodyIndex: index.New([]*article.Article{
+			{URL: "https://example.com/chips", Title: "Chips", Content: "Taiwan dominates semiconductor fabrication."},
+		}),
+		searchQuery: "semiconductor",
+	}
+
+	m.applySearch()
+	if len(m.filteredItems) != 1 || m.filteredItems[0].Link != "https://example.com/chips" {
+		t.Fatalf("expected body match, got %#v", m.filteredItems)
+	}
+	if got := m.listItem(m.filteredItems[0], false).Subtitle; !strings.Contains(got, "semiconductor") {
+		t.Fatalf("expected snippet subtitle, got %q", got)
+	}
+}
+
+func TestApplySearchBodiesHonourOrAndExclusions(t *testing.T) {
+	items := []rss.Item{
+		{Title: "Chips", Link: "https://example.com/chips"},
+		{Title: "Ships", Link: "https://example.com/ships"},
+		{Title: "Tariffs", Link: "https://example.com/tariffs"},
+	}
+	m := Model{
+		allItems:      items,
+		filteredItems: items,
+		bodySearch:    true,
+		bodyIndex: index.New([]*article.Article{
+			{URL: "https://example.com/chips", Title: "Chips", Content: "Taiwan dominates semiconductor fabrication."},
+			{URL: "https://example.com/ships", Title: "Ships", Content: "Container shipping rates doubled."},
+			{URL: "https://example.com/tariffs", Title: "Tariffs", Content: "New duties on semiconductor imports."},
+		}),
+		searchQuery: "semiconductor OR shipping -duties",
+	}
+
+	m.applySearch()
+	var got []string
+	for _, item := range m.filteredItems {
+		got = append(got, item.Link)
+	}
+	if len(got) != 2 || got[0] != "https://example.com/chips" || got[1] != "https://example.com/ships" {
+		t.Fatalf("expected either branch without excluded bodies, got %v", got)
+	}
+}
+
+func TestAllSectionsModelTagsAndFiltersBySection(t *testing.T) {
+	m := NewAllSectionsModel(Options{NoColor: true}, nil)
+	if len(m.sections) != 0 || !m.sectionLoading {
+		t.Fatalf("expected a loading model without section tabs")
+	}
+
+	next, _ := m.Update(allSectionsMsg{items: []rss.TaggedItem{
+		{Item: rss.Item{Title: "Rates", Link: "https://example.com/rates"}, Sections: []string{"finance"}},
+		{Item: rss.Item{Title: "Chips", Link: "https://example.com/chips"}, Sections: []string{"business", "asia"}},
+	}})
+	m = next.(Model)
+	if m.sectionLoading || len(m.filteredItems) != 2 {
+		t.Fatalf("expected loaded items, got loading=%t items=%d", m.sectionLoading, len(m.filteredItems))
+	}
+	if badge := m.listItem(m.filteredItems[1], false).Badge; !strings.Contains(badge, "BUSINESS · ASIA") {
+		t.Fatalf("expected section badge, got %q", badge)
+	}
+
+	m.searchQuery = "section:asia"
+	m.applySearch()
+	if len(m.filteredItems) != 1 || m.filteredItems[0].Link != "https://example.com/chips" {
+		t.Fatalf("expected section filter to match tag, got %#v", m.filteredItems)
+	}
+}
+
+func TestEditionModelGroupsBySection(t *testing.T) {
+	m := NewEditionModel(Options{NoColor: true}, demo.NewSource())
+	msg := m.fetchEditionCmd()()
+	next, _ := m.Update(msg)
+	m = next.(Model)
+	if m.sectionErr != nil || len(m.filteredItems) == 0 {
+		t.Fatalf("expected edition items, got err=%v items=%d", m.sectionErr, len(m.filteredItems))
+	}
+	if !strings.HasPrefix(m.sectionTitle, "Weekly edition: ") {
+		t.Fatalf("expected dated title, got %q", m.sectionTitle)
+	}
+
+	first := m.itemSections[m.filteredItems[0].Link][0]
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(Model)
+	if m.cursor == 0 || m.itemSections[m.filteredItems[m.cursor].Link][0] == first {
+		t.Fatalf("expected tab to jump past %s, cursor at %d", first, m.cursor)
+	}
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m = next.(Model); m.cursor != 0 {
+		t.Fatalf("expected shift+tab to return to the first section, got %d", m.cursor)
+	}
+}
+
+func TestHideReadItems(t *testing.T) {
+	items := []rss.Item{
+		{Title: "Rates", Link: "https://example.com/rates"},
+		{Title: "Chips", Link: "https://example.com/chips"},
+	}
+	state := readstate.New()
+	state.Mark(items[0].Keys()...)
+	m := Model{allItems: items, filteredItems: items, readState: state}
+
+	if !m.listItem(items[0], false).Read || m.listItem(items[1], false).Read {
+		t.Fatalf("expected only the first item to render as read")
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = next.(Model)
+	if !m.hideRead || len(m.filteredItems) != 1 || m.filteredItems[0].Link != items[1].Link {
+		t.Fatalf("expected read item hidden, got %#v", m.filteredItems)
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = next.(Model)
+	if len(m.filteredItems) != 2 {
+		t.Fatalf("expected read items shown again, got %d", len(m.filteredItems))
+	}
+}
+
+func TestToggleBookmarkShowsInBookmarksScreen(t *testing.T) {
+	source := demo.NewSource()
+	title, items, err := source.Section("leaders")
+	if err != nil || len(items) == 0 {
+		t.Fatalf("demo section: %v", err)
+	}
+	m := NewModel("leaders", items, title, Options{NoColor: true}, source)
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = next.(Model)
+	if got := m.listItem(items[0], false).Title; !strings.HasPrefix(got, bookmarkMarker) {
+		t.Fatalf("expected starred title, got %q", got)
+	}
+
+	bm := NewBookmarksModel(Options{NoColor: true}, source)
+	next, _ = bm.Update(bm.fetchBookmarksCmd()())
+	bm = next.(Model)
+	if len(bm.filteredItems) != 1 || bm.filteredItems[0].Link != items[0].Link {
+		t.Fatalf("expected bookmarked item listed, got %#v", bm.filteredItems)
+	}
+	if badge := bm.listItem(bm.filteredItems[0], false).Badge; !strings.Contains(badge, "LEADERS") {
+		t.Fatalf("expected section badge from bookmark, got %q", badge)
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = next.(Model)
+	if m.isBookmarked(items[0]) {
+		t.Fatalf("expected second toggle to remove bookmark")
+	}
+}
+
+type prefetchSource struct {
+	*demo.Source
+	urls []string
+	err  error
+}
+
+func (s *prefetchSource) Prefetch(urls []string) error {
+	s.urls = append(s.urls, urls...)
+	return s.err
+}
+
+func TestPrefetchVisibleItems(t *testing.T) {
+	source := &prefetchSource{Source: demo.NewSource()}
+	tagged, err := source.AllSections()
+	if err != nil || len(tagged) <= prefetchCount {
+		t.Fatalf("demo sections: %v (%d items)", err, len(tagged))
+	}
+	items := make([]rss.Item, len(tagged))
+	for i, item := range tagged {
+		items[i] = item.Item
+	}
+	m := NewModel("leaders", items, "Demo", Options{NoColor: true}, source)
+
+	source.err = errors.New("daemon not running")
+	next, _ := m.Update(m.prefetchCmd()())
+	m = next.(Model)
+	source.err = nil
+	source.urls = nil
+	next, _ = m.Update(m.prefetchCmd()())
+	m = next.(Model)
+	if len(source.urls) != prefetchCount || source.urls[0] != items[0].Link {
+		t.Fatalf("expected the first %d items prefetched after a failure, got %v", prefetchCount, source.urls)
+	}
+
+	source.urls = nil
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyUp}); cmd != nil {
+		t.Fatalf("expected no prefetch when nothing new is visible")
+	}
+	m.cursor = prefetchCount
+	_, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	if cmd == nil {
+		t.Fatalf("expected prefetch for the item under the cursor")
+	}
+	cmd()
+	if len(source.urls) != 1 || source.urls[0] != items[prefetchCount].Link {
+		t.Fatalf("expected cursor item prefetched, got %v", source.urls)
+	}
+}
+
+type offlineSource struct {
+	*demo.Source
+	cached  map[string]bool
+	lookups *int
+}
+
+func (s offlineSource) Offline() bool { return true }
+
+func (s offlineSource) Available(urls []string) map[string]bool {
+	if s.lookups != nil {
+		*s.lookups++
+	}
+	available := make(map[string]bool)
+	for _, url := range urls {
+		available[url] = s.cached[url]
+	}
+	return available
+}
+
+func TestOfflineMarksUnavailableItems(t *testing.T) {
+	items := []rss.Item{
+		{Title: "Rates", Link: "https://example.com/rates"},
+		{Title: "Chips", Link: "https://example.com/chips"},
+	}
+	lookups := 0
+	source := offlineSource{Source: demo.NewSource(), cached: map[string]bool{items[0].Link: true}, lookups: &lookups}
+	m := NewModel("leaders", items, "Leaders", Options{NoColor: true}, source)
+	if detail := m.listItem(items[1], false).Detail; detail != "" || lookups != 0 {
+		t.Fatalf("expected rendering not to look up availability, got %q after %d lookups", detail, lookups)
+	}
+	next, _ := m.Update(m.availableCmd()())
+	m = next.(Model)
+	if m.availableCmd() != nil {
+		t.Fatalf("expected checked links not looked up again")
+	}
+
+	if detail := m.listItem(items[0], false).Detail; detail != "" {
+		t.Fatalf("expected cached item unmarked, got %q", detail)
+	}
+	if detail := m.listItem(items[1], false).Detail; detail != unavailableDetail {
+		t.Fatalf("expected uncached item marked, got %q", detail)
+	}
+	if lookups != 1 {
+		t.Fatalf("expected one batched availability lookup, got %d", lookups)
+	}
+	if bar := m.renderSearchBar(ui.NewBrowseStyles(true), 80); !strings.Contains(bar, "offline") {
+		t.Fatalf("expected offline in search bar, got %q", bar)
+	}
+}
+
+func TestResumeReadingPosition(t *testing.T) {
+	source := demo.NewSource()
+	title, items, err := source.Section("leaders")
+	if err != nil || len(items) == 0 {
+		t.Fatalf("demo section: %v", err)
+	}
+	m := NewModel("leaders", items, title, Options{NoColor: true}, source)
+	m.width, m.height = 100, 16
+
+	open := func(m Model) Model {
+		next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = next.(Model)
+		next, _ = m.Update(cmd())
+		return next.(Model)
+	}
+
+	m = open(m)
+	if m.article == nil || m.scroll != 0 {
+		t.Fatalf("expected article at top, got article=%v scroll=%d", m.article != nil, m.scroll)
+	}
+	for i := 0; i < 24; i++ {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = next.(Model)
+	}
+	paragraph := m.currentParagraph()
+	if paragraph == 0 {
+		t.Fatalf("expected to scroll past the first paragraph")
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = next.(Model)
+	detail := m.listItem(items[0], false).Detail
+	if !strings.HasSuffix(detail, "% read") || detail == "100% read" {
+		t.Fatalf("expected progress detail, got %q", detail)
+	}
+
+	m.width = 70
+	m = open(m)
+	if got := m.currentParagraph(); got != paragraph {
+		t.Fatalf("expected to resume at paragraph %d after re-wrap, got %d", paragraph, got)
+	}
+}
+
+type linkedSource map[string]*article.Article
+
+func (s linkedSource) Section(string) (string, []rss.Item, error) { return "Leaders", nil, nil }
+
+func (s linkedSource) Article(url string) (*article.Article, error) { return s[url], nil }
+
+func TestFollowLinkOpensArticleInPlace(t *testing.T) {
+	t.Setenv("ECONOMIST_HYPERLINKS", "0")
+	first := "https://www.economist.com/leaders/2026/01/01/first"
+	second := "https://www.economist.com/briefing/2025/12/01/second"
+	source := linkedSource{
+		first: {URL: first, Title: "First", Blocks: []article.Block{{Kind: article.BlockParagraph, Spans: []article.Span{
+			{Text: "See "},
+			{Text: "an outside source", Link: "https://example.com/report"},
+			{Text: " and "},
+			{Text: "our briefing", Link: second},
+		}}, {Kind: article.BlockFigure, Src: "https://www.economist.com/content-assets/images/chart.png", Spans: []article.Span{{Text: "Growth"}}, Credit: "Chart: The Economist"}}},
+		second: {URL: second, Title: "Second", Content: "Second body."},
+	}
+	items := []rss.Item{{Title: "First", Link: first}}
+	m := NewModel("leaders", items, "Leaders", Options{NoColor: true}, source)
+	m.width, m.height = 100, 24
+
+	press := func(m Model, msg tea.KeyMsg) Model {
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		if cmd != nil {
+			next, _ = m.Update(cmd())
+			m = next.(Model)
+		}
+		return m
+	}
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.article == nil || m.article.Title != "First" {
+		t.Fatalf("expected first article open")
+	}
+	if !strings.Contains(strings.Join(m.articleLines, "\n"), "[2] "+second) {
+		t.Fatalf("expected link footnotes in article, got %q", m.articleLines)
+	}
+
+	m = press(m, key("l"))
+	m = press(m, key("o"))
+	if m.article.Title != "First" || !strings.Contains(m.statusMsg, "example.com") {
+		t.Fatalf("expected external link to stay put, got %q status %q", m.article.Title, m.statusMsg)
+	}
+
+	m = press(m, key("l"))
+	m = press(m, key("o"))
+	if m.article == nil || m.article.Title != "Second" || m.mode != modeArticle {
+		t.Fatalf("expected linked article opened in place")
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.article == nil || m.article.Title != "First" || len(m.linkHistory) != 0 {
+		t.Fatalf("expected backspace to return to first article")
+	}
+
+	var opened string
+	openURL = func(url string) error { opened = url; return nil }
+	defer func() { openURL = browser.Open }()
+	m = press(m, key("L"))
+	if !strings.Contains(m.statusMsg, "[Chart: Growth]") {
+		t.Fatalf("expected chart placeholder selected, got %q", m.statusMsg)
+	}
+	m = press(m, key("o"))
+	if opened != "https://www.economist.com/content-assets/images/chart.png" || m.article.Title != "First" {
+		t.Fatalf("expected chart image opened outside the reader, got %q", opened)
+	}
+}
+
+type trackedLinkSource struct {
+	linkedSource
+	state *readstate.State
+}
+
+func (s trackedLinkSource) ReadState() (*readstate.State, error) { return s.state, nil }
+
+func (s trackedLinkSource) MarkRead(keys ...string) error { s.state.Mark(keys...); return nil }
+
+func (s trackedLinkSource) SavePosition(string, readstate.Position) error { return nil }
+
+func TestFollowLinkMarksReadOnLoadAndKeepsTitle(t *testing.T) {
+	t.Setenv("ECONOMIST_HYPERLINKS", "0")
+	first := "https://www.economist.com/leaders/2026/01/01/first"
+	second := "https://www.economist.com/briefing/2025/12/01/second"
+	source := trackedLinkSource{
+		linkedSource: linkedSource{
+			first: {URL: first, Title: "First", Blocks: []article.Block{{Kind: article.BlockParagraph, Spans: []article.Span{
+				{Text: "our briefing", Link: second},
+			}}}},
+			second: {URL: second, Title: "Second", Content: "Second body."},
+		},
+		state: readstate.New(),
+	}
+	items := []rss.Item{{Title: "First headline", Link: first}}
+	m := NewModel("leaders", items, "Leaders", Options{NoColor: true}, source)
+	m.width, m.height = 100, 24
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = next.(Model).Update(cmd())
+	next, _ = next.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	next, cmd = next.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = next.(Model)
+	if source.state.IsRead(rss.Item{Link: second}.Keys()...) {
+		t.Fatalf("expected followed link unread before it loads")
+	}
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if !source.state.IsRead(rss.Item{Link: second}.Keys()...) {
+		t.Fatalf("expected followed link read once loaded")
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = next.(Model)
+	if m.loadingItem == nil || m.loadingItem.Title != "First headline" {
+		t.Fatalf("expected backspace to show the previous headline while loading, got %v", m.loadingItem)
+	}
+}
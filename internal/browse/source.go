@@ -2,9 +2,16 @@ package browse
 
 import (
 	"strings"
+	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
 	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/index"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 )
 
@@ -13,6 +20,56 @@ type DataSource interface {
 	Article(url string) (*article.Article, error)
 }
 
+// ArticleSaver is implemented by sources that can keep articles in the library.
+type ArticleSaver interface {
+	SaveArticle(art *article.Article) error
+}
+
+// AllSectionsSource is implemented by sources that can fetch every section at
+// once. Items are tagged with the sections they appeared in.
+type AllSectionsSource interface {
+	AllSections() ([]rss.TaggedItem, error)
+}
+
+// EditionSource is implemented by sources that can assemble a weekly issue.
+// A zero issue date asks for the latest one.
+type EditionSource interface {
+	Edition(issue time.Time) (rss.Edition, error)
+}
+
+// Prefetcher is implemented by sources that can warm article bodies in the
+// background before they are opened. URLs whose Prefetch fails are offered
+// again later.
+type Prefetcher interface {
+	Prefetch(urls []string) error
+}
+
+// OfflineSource is implemented by sources that can lose the network. While
+// Offline, only articles that Available reports can be opened.
+type OfflineSource interface {
+	Offline() bool
+	Available(urls []string) map[string]bool
+}
+
+// ReadTracker is implemented by sources that remember which items were opened
+// and where reading stopped.
+type ReadTracker interface {
+	ReadState() (*readstate.State, error)
+	MarkRead(keys ...string) error
+	SavePosition(url string, pos readstate.Position) error
+}
+
+// Bookmarker is implemented by sources that keep starred headlines.
+type Bookmarker interface {
+	Bookmarks() ([]bookmarks.Bookmark, error)
+	ToggleBookmark(item rss.Item, sections []string) (bool, error)
+}
+
+// BodyIndexer is implemented by sources that can search article bodies.
+type BodyIndexer interface {
+	BodyIndex() (*index.Index, error)
+}
+
 type rssSource struct {
 	debug bool
 }
@@ -28,3 +85,63 @@ func (s rssSource) Section(section string) (string, []rss.Item, error) {
 func (s rssSource) Article(url string) (*article.Article, error) {
 	return fetch.FetchArticle(url, fetch.Options{Debug: s.debug})
 }
+
+func (s rssSource) Prefetch(urls []string) error {
+	err := fetch.Prefetch(urls)
+	if err != nil {
+		logging.Debugf(s.debug, "browse: prefetch error: %v", err)
+	}
+	return err
+}
+
+func (s rssSource) Offline() bool {
+	return offline.Enabled()
+}
+
+func (s rssSource) Available(urls []string) map[string]bool {
+	return fetch.Available(urls)
+}
+
+func (s rssSource) SaveArticle(art *article.Article) error {
+	return library.Save(art)
+}
+
+func (s rssSource) ReadState() (*readstate.State, error) {
+	return readstate.Load()
+}
+
+func (s rssSource) MarkRead(keys ...string) error {
+	return readstate.MarkRead(keys...)
+}
+
+func (s rssSource) SavePosition(url string, pos readstate.Position) error {
+	return readstate.SavePosition(url, pos)
+}
+
+func (s rssSource) Bookmarks() ([]bookmarks.Bookmark, error) {
+	return bookmarks.List()
+}
+
+func (s rssSource) ToggleBookmark(item rss.Item, sections []string) (bool, error) {
+	return bookmarks.Toggle(item, sections)
+}
+
+func (s rssSource) BodyIndex() (*index.Index, error) {
+	return index.Update()
+}
+
+func (s rssSource) AllSections() ([]rss.TaggedItem, error) {
+	items, err := rss.FetchSections(rss.AllSections())
+	if len(items) > 0 {
+		return items, nil
+	}
+	return nil, err
+}
+
+func (s rssSource) Edition(issue time.Time) (rss.Edition, error) {
+	edition, err := rss.FetchEdition(issue)
+	if edition.Len() > 0 {
+		return edition, nil
+	}
+	return edition, err
+}
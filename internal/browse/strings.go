@@ -1,17 +1,27 @@
 package browse
 
 const (
-	articleHelpFormat      = "b back • ⇧⇥/⇥ prev/next • c columns %s • ↑/↓ scroll • q quit"
-	articleLoadingHelp     = "b back • ⇧⇥/⇥ prev/next • q quit"
-	browseTitleLines       = 2
-	browseSubtitleLines    = 2
-	browseHeaderLines      = 5
-	browseFooterPadding    = 1
-	browseFooterGapLines   = 0
-	browseMinVisibleLines  = 5
-	browseItemGapLines     = 1
-	articleFooterLines     = 4
-	articleFooterPadding   = 1
-	articleFooterGapLines  = 0
-	articleMinVisibleLines = 5
+	articleHelpFormat        = "b back • ⇧⇥/⇥ prev/next • l/o links • c columns %s • s save • ↑/↓ scroll • q quit"
+	articleLoadingHelp       = "b back • ⇧⇥/⇥ prev/next • q quit"
+	allSectionsTitle         = "All sections"
+	allSectionsLimit         = 200
+	editionTitle             = "Weekly edition"
+	editionEmpty             = "No articles found for this issue - feeds only cover recent weeks"
+	bookmarksTitle           = "Bookmarks"
+	bookmarksEmpty           = "No bookmarks yet - press ^s on a headline to star it"
+	bookmarkMarker           = "★ "
+	prefetchCount            = 5
+	unavailableDetail        = "not available offline"
+	unavailableDetailCompact = "offline"
+	browseTitleLines         = 2
+	browseSubtitleLines      = 2
+	browseHeaderLines        = 5
+	browseFooterPadding      = 1
+	browseFooterGapLines     = 0
+	browseMinVisibleLines    = 5
+	browseItemGapLines       = 1
+	articleFooterLines       = 4
+	articleFooterPadding     = 1
+	articleFooterGapLines    = 0
+	articleMinVisibleLines   = 5
 )
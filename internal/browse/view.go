@@ -5,6 +5,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
@@ -41,6 +42,10 @@ func (m Model) browseView() (string, string) {
 		statusLine = styles.Dim.Render(fmt.Sprintf("loading %s…", m.pendingSection))
 	} else if m.sectionErr != nil {
 		statusLine = styles.Dim.Render(fmt.Sprintf("error: %v", m.sectionErr))
+	} else if m.bodyErr != nil {
+		statusLine = styles.Dim.Render(fmt.Sprintf("body search: %v", m.bodyErr))
+	} else if m.statusMsg != "" {
+		statusLine = styles.Dim.Render(m.statusMsg)
 	}
 
 	// Search bar with states: idle, active, no-match
@@ -55,7 +60,14 @@ func (m Model) browseView() (string, string) {
 	layout := browseLayout{}
 
 	if len(items) == 0 {
-		b.WriteString("\n" + styles.Dim.Render("  No matching articles") + "\n")
+		empty := "  No matching articles"
+		if m.bookmarksView && len(m.allItems) == 0 && !m.sectionLoading {
+			empty = "  " + bookmarksEmpty
+		}
+		if m.edition && len(m.allItems) == 0 && !m.sectionLoading {
+			empty = "  " + editionEmpty
+		}
+		b.WriteString("\n" + styles.Dim.Render(empty) + "\n")
 	} else {
 		layout = m.browseLayout(len(items))
 		maxVisible = layout.maxVisible
@@ -85,15 +97,7 @@ func (m Model) browseView() (string, string) {
 
 		listItems := make([]ui.ListItem, len(items))
 		for i, item := range items {
-			date := item.FormattedDate()
-			if dateLayout.Compact {
-				date = item.CompactDate()
-			}
-			listItems[i] = ui.ListItem{
-				Title:    item.CleanTitle(),
-				Subtitle: item.CleanDescription(),
-				Right:    date,
-			}
+			listItems[i] = m.listItem(item, dateLayout.Compact)
 		}
 
 		listOpts := ui.ListOptions{
@@ -116,6 +120,7 @@ func (m Model) browseView() (string, string) {
 			Selected:      styles.Selected,
 			Right:         styles.Dim,
 			RightSelected: styles.Selected,
+			Read:          styles.Read,
 		}
 
 		b.WriteString(ui.RenderList(listItems, listOpts, listStyles))
@@ -239,6 +244,14 @@ func (m Model) articleView() (string, string) {
 		}
 		hintLine = styles.Dim.Render(fmt.Sprintf("%d%% · more ↓", pct))
 	}
+	if m.statusMsg != "" {
+		status := styles.Dim.Render(m.statusMsg)
+		if hintLine != "" {
+			hintLine = status + styles.Dim.Render(" · ") + hintLine
+		} else {
+			hintLine = status
+		}
+	}
 
 	lastLine := lastNonBlankLine(m.articleLines[start:end])
 	if ui.IsRuleLine(lastLine) {
@@ -268,6 +281,12 @@ func (m Model) loadingSkeletonView() string {
 	}
 
 	if m.loadingItem != nil {
+		if tags := m.itemSections[m.loadingItem.Link]; len(tags) > 0 {
+			header.Section = tags[0]
+			if m.edition {
+				header.Section = rss.SectionTitle(tags[0])
+			}
+		}
 		header.Title = m.loadingItem.CleanTitle()
 		header.Subtitle = m.loadingItem.CleanDescription()
 		header.Date = m.loadingItem.FormattedDate()
@@ -331,7 +350,17 @@ func (m Model) renderSearchBar(styles ui.BrowseStyles, width int) string {
 
 	if m.searchQuery == "" {
 		// Idle state: show placeholder
-		return styles.SearchIdle.Render("/ type to filter...")
+		placeholder := "/ type to filter..."
+		if m.bodySearch {
+			placeholder = "/ type to search headlines and bodies..."
+		}
+		if m.hideRead {
+			placeholder += " · unread only"
+		}
+		if m.offline() {
+			placeholder += " · offline"
+		}
+		return styles.SearchIdle.Render(placeholder)
 	}
 
 	// Active state with query
@@ -347,5 +376,14 @@ func (m Model) renderSearchBar(styles ui.BrowseStyles, width int) string {
 	// Normal active state with results - unified background
 	text := fmt.Sprintf("%s %s%s", prefix, m.searchQuery, cursor)
 	countText := fmt.Sprintf("%d of %d", matchCount, totalItems)
+	if m.bodySearch {
+		countText += " · bodies"
+	}
+	if m.hideRead {
+		countText += " · unread"
+	}
+	if m.offline() {
+		countText += " · offline"
+	}
 	return styles.SearchActive.Render(text) + styles.SearchCount.Render(countText)
 }
@@ -0,0 +1,513 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	boltFileName = "cache.db"
+	// boltTimeout bounds how long an operation waits for another process
+	// that has the database open for writing.
+	boltTimeout = 5 * time.Second
+)
+
+// Buckets in the bolt database. Index keys start with the section or the
+// fetch time, so range scans answer queries without decoding every article.
+var (
+	bucketArticles   = []byte("articles")   // url -> articleEntry JSON
+	bucketFeeds      = []byte("feeds")      // section -> feedEntry JSON
+	bucketBySection  = []byte("by_section") // section \x00 url -> empty
+	bucketByTime     = []byte("by_time")    // cached_at (8 bytes) url -> empty
+	bucketReads      = []byte("reads")      // kind \x00 key -> last read (8 bytes)
+	bucketQuarantine = []byte("quarantine") // quarantined at (8 bytes) bucket \x00 key -> value
+
+	boltBuckets = [][]byte{bucketArticles, bucketFeeds, bucketBySection, bucketByTime, bucketReads, bucketQuarantine}
+)
+
+const (
+	readArticle = 'a'
+	readFeed    = 'f'
+)
+
+// boltStore keeps the cache in a single bbolt database file. The database is
+// opened for each operation rather than held open, since bbolt locks the
+// whole file and the daemon and CLI share it.
+type boltStore struct {
+	path string
+}
+
+func newBoltStore(path string) *boltStore {
+	return &boltStore{path: path}
+}
+
+func boltPath() string {
+	return filepath.Join(cacheDir(), boltFileName)
+}
+
+func (s *boltStore) view(fn func(tx *bolt.Tx) error) error {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: boltTimeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketArticles) == nil {
+			return nil
+		}
+		return fn(tx)
+	})
+}
+
+func (s *boltStore) update(fn func(tx *bolt.Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: boltTimeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+func (s *boltStore) Article(url string) (*Entry, bool, error) {
+	var entry *Entry
+	var decodeErr error
+	err := s.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketArticles).Get([]byte(url))
+		if data == nil {
+			return nil
+		}
+		var cached articleEntry
+		if decodeErr = json.Unmarshal(data, &cached); decodeErr != nil {
+			return nil
+		}
+		entry = &Entry{CachedAt: cached.CachedAt, Article: cached.Article, ReadAt: readTime(tx, readArticle, url)}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if decodeErr != nil {
+		_ = s.quarantine(bucketArticles, url)
+		return nil, false, decodeErr
+	}
+	return entry, entry != nil, nil
+}
+
+func (s *boltStore) PutArticle(entry Entry) error {
+	data, err := json.Marshal(articleEntry{CachedAt: entry.CachedAt, Article: entry.Article})
+	if err != nil {
+		return err
+	}
+	url := entry.Article.URL
+	return s.update(func(tx *bolt.Tx) error {
+		if err := deleteArticle(tx, url); err != nil {
+			return err
+		}
+		if err := tx.Bucket(bucketArticles).Put([]byte(url), data); err != nil {
+			return err
+		}
+		if section := articleSection(url); section != "" {
+			if err := tx.Bucket(bucketBySection).Put(sectionKey(section, url), nil); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket(bucketByTime).Put(timeKey(entry.CachedAt, url), nil); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketReads).Put(readKey(readArticle, url), encodeTime(time.Now()))
+	})
+}
+
+func (s *boltStore) DeleteArticle(url string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		return deleteArticle(tx, url)
+	})
+}
+
+func (s *boltStore) DeleteArticles(urls []string) (int, error) {
+	removed := 0
+	err := s.update(func(tx *bolt.Tx) error {
+		articles := tx.Bucket(bucketArticles)
+		for _, url := range urls {
+			if articles.Get([]byte(url)) == nil {
+				continue
+			}
+			if err := deleteArticle(tx, url); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+func (s *boltStore) Articles(q Query) ([]Entry, error) {
+	var entries []Entry
+	err := s.view(func(tx *bolt.Tx) error {
+		articles := tx.Bucket(bucketArticles)
+		add := func(url []byte) {
+			data := articles.Get(url)
+			if data == nil {
+				return
+			}
+			var cached articleEntry
+			if json.Unmarshal(data, &cached) != nil {
+				return
+			}
+			entry := Entry{CachedAt: cached.CachedAt, Article: cached.Article, ReadAt: readTime(tx, readArticle, string(url))}
+			if q.matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+
+		switch {
+		case len(q.URLs) > 0:
+			for _, url := range q.URLs {
+				add([]byte(url))
+			}
+		case q.Section != "":
+			prefix := sectionKey(q.Section, "")
+			c := tx.Bucket(bucketBySection).Cursor()
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				add(k[len(prefix):])
+			}
+		case !q.After.IsZero() || !q.Before.IsZero():
+			c := tx.Bucket(bucketByTime).Cursor()
+			end := timeKey(q.Before, "")
+			for k, _ := c.Seek(timeKey(q.After, "")); k != nil; k, _ = c.Next() {
+				if !q.Before.IsZero() && bytes.Compare(k, end) >= 0 {
+					break
+				}
+				add(k[8:])
+			}
+		default:
+			return articles.ForEach(func(k, _ []byte) error {
+				add(k)
+				return nil
+			})
+		}
+		return nil
+	})
+	sortNewestFirst(entries)
+	return entries, err
+}
+
+func (s *boltStore) TouchArticle(url string, at time.Time) error {
+	return s.touch(bucketArticles, readArticle, url, at)
+}
+
+func (s *boltStore) Feed(section string) (*FeedEntry, bool, error) {
+	var entry *FeedEntry
+	var decodeErr error
+	err := s.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketFeeds).Get([]byte(section))
+		if data == nil {
+			return nil
+		}
+		var cached feedEntry
+		if decodeErr = json.Unmarshal(data, &cached); decodeErr != nil {
+			return nil
+		}
+		entry = cached.toFeedEntry(section)
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if decodeErr != nil {
+		_ = s.quarantine(bucketFeeds, section)
+		return nil, false, decodeErr
+	}
+	return entry, entry != nil, nil
+}
+
+func (s *boltStore) PutFeed(entry FeedEntry) error {
+	data, err := json.Marshal(newFeedEntry(entry))
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketFeeds).Put([]byte(entry.Section), data); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketReads).Put(readKey(readFeed, entry.Section), encodeTime(time.Now()))
+	})
+}
+
+func (s *boltStore) TouchFeed(section string, at time.Time) error {
+	return s.touch(bucketFeeds, readFeed, section, at)
+}
+
+func (s *boltStore) DeleteFeeds(cutoff time.Time) (int, error) {
+	removed := 0
+	err := s.update(func(tx *bolt.Tx) error {
+		var sections []string
+		err := tx.Bucket(bucketFeeds).ForEach(func(k, v []byte) error {
+			var cached feedEntry
+			if !cutoff.IsZero() && json.Unmarshal(v, &cached) == nil && !cached.CachedAt.Before(cutoff) {
+				return nil
+			}
+			sections = append(sections, string(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, section := range sections {
+			if err := deleteFeed(tx, section); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+func (s *boltStore) Maintain(settings Settings) (MaintainResult, error) {
+	var result MaintainResult
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return result, nil
+	}
+	now := time.Now()
+	articleCutoff, feedCutoff := settings.cutoffs(now)
+
+	err := s.update(func(tx *bolt.Tx) error {
+		// Expired articles come straight off the fetch time index.
+		var expired []string
+		c := tx.Bucket(bucketByTime).Cursor()
+		end := timeKey(articleCutoff, "")
+		for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+			expired = append(expired, string(k[8:]))
+		}
+		for _, url := range expired {
+			if err := deleteArticle(tx, url); err != nil {
+				return err
+			}
+			result.Expired++
+		}
+
+		type sized struct {
+			bucket []byte
+			key    string
+			size   int64
+			readAt time.Time
+		}
+		var kept []sized
+		err := tx.Bucket(bucketFeeds).ForEach(func(k, v []byte) error {
+			var cached feedEntry
+			if json.Unmarshal(v, &cached) != nil || cached.CachedAt.Before(feedCutoff) {
+				kept = append(kept, sized{bucket: bucketFeeds, key: string(k), size: -1})
+				return nil
+			}
+			kept = append(kept, sized{bucket: bucketFeeds, key: string(k), size: int64(len(k) + len(v)), readAt: readTime(tx, readFeed, string(k))})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(bucketArticles).ForEach(func(k, v []byte) error {
+			kept = append(kept, sized{bucket: bucketArticles, key: string(k), size: int64(len(k) + len(v)), readAt: readTime(tx, readArticle, string(k))})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		live := kept[:0]
+		for _, entry := range kept {
+			if entry.size < 0 {
+				if err := deleteFeed(tx, entry.key); err != nil {
+					return err
+				}
+				result.Expired++
+				continue
+			}
+			live = append(live, entry)
+			result.Bytes += entry.size
+		}
+
+		sort.Slice(live, func(i, j int) bool {
+			return live[i].readAt.Before(live[j].readAt)
+		})
+		for _, entry := range live {
+			if result.Bytes <= settings.MaxBytes {
+				break
+			}
+			var err error
+			if bytes.Equal(entry.bucket, bucketFeeds) {
+				err = deleteFeed(tx, entry.key)
+			} else {
+				err = deleteArticle(tx, entry.key)
+			}
+			if err != nil {
+				return err
+			}
+			result.Evicted++
+			result.Bytes -= entry.size
+		}
+
+		var stale [][]byte
+		qc := tx.Bucket(bucketQuarantine).Cursor()
+		qend := timeKey(now.Add(-quarantineTTL), "")
+		for k, _ := qc.First(); k != nil && bytes.Compare(k, qend) < 0; k, _ = qc.Next() {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		for _, k := range stale {
+			if err := tx.Bucket(bucketQuarantine).Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (s *boltStore) Stats() (Usage, error) {
+	usage := Usage{Backend: BackendBolt, Path: s.path}
+	err := s.view(func(tx *bolt.Tx) error {
+		err := tx.Bucket(bucketArticles).ForEach(func(k, v []byte) error {
+			usage.Articles++
+			usage.ArticleBytes += int64(len(k) + len(v))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(bucketFeeds).ForEach(func(k, v []byte) error {
+			usage.Feeds++
+			usage.FeedBytes += int64(len(k) + len(v))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		usage.Quarantined = tx.Bucket(bucketQuarantine).Stats().KeyN
+		return nil
+	})
+	return usage, err
+}
+
+func (s *boltStore) touch(bucket []byte, kind byte, key string, at time.Time) error {
+	return s.update(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucket).Get([]byte(key)) == nil {
+			return nil
+		}
+		return tx.Bucket(bucketReads).Put(readKey(kind, key), encodeTime(at))
+	})
+}
+
+// quarantine moves a value that does not decode out of bucket, keeping it
+// for a week in the quarantine bucket.
+func (s *boltStore) quarantine(bucket []byte, key string) error {
+	return s.update(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		qkey := timeKey(time.Now(), string(bucket)+"\x00"+key)
+		if err := tx.Bucket(bucketQuarantine).Put(qkey, append([]byte(nil), data...)); err != nil {
+			return err
+		}
+		if bytes.Equal(bucket, bucketFeeds) {
+			return deleteFeed(tx, key)
+		}
+		return deleteArticle(tx, key)
+	})
+}
+
+// deleteArticle removes url and its index entries. The fetch time index key
+// needs the stored cached_at, so a value that does not decode leaves its
+// time index entry behind; readers skip index entries without a value.
+func deleteArticle(tx *bolt.Tx, url string) error {
+	articles := tx.Bucket(bucketArticles)
+	data := articles.Get([]byte(url))
+	if data == nil {
+		return nil
+	}
+	var cached articleEntry
+	if json.Unmarshal(data, &cached) == nil {
+		if err := tx.Bucket(bucketByTime).Delete(timeKey(cached.CachedAt, url)); err != nil {
+			return err
+		}
+	}
+	if section := articleSection(url); section != "" {
+		if err := tx.Bucket(bucketBySection).Delete(sectionKey(section, url)); err != nil {
+			return err
+		}
+	}
+	if err := tx.Bucket(bucketReads).Delete(readKey(readArticle, url)); err != nil {
+		return err
+	}
+	return articles.Delete([]byte(url))
+}
+
+func deleteFeed(tx *bolt.Tx, section string) error {
+	if err := tx.Bucket(bucketReads).Delete(readKey(readFeed, section)); err != nil {
+		return err
+	}
+	return tx.Bucket(bucketFeeds).Delete([]byte(section))
+}
+
+func readTime(tx *bolt.Tx, kind byte, key string) time.Time {
+	return decodeTime(tx.Bucket(bucketReads).Get(readKey(kind, key)))
+}
+
+func sectionKey(section, url string) []byte {
+	return []byte(section + "\x00" + url)
+}
+
+func readKey(kind byte, key string) []byte {
+	return append([]byte{kind, 0}, key...)
+}
+
+// timeKey prefixes key with t as big-endian nanoseconds, so keys sort by
+// time. Times before 1970 sort first.
+func timeKey(t time.Time, key string) []byte {
+	return append(encodeTime(t), key...)
+}
+
+func encodeTime(t time.Time) []byte {
+	var nanos uint64
+	if !t.IsZero() && t.UnixNano() > 0 {
+		nanos = uint64(t.UnixNano())
+	}
+	buf := make([]byte, 8, 8+64)
+	binary.BigEndian.PutUint64(buf, nanos)
+	return buf
+}
+
+func decodeTime(data []byte) time.Time {
+	if len(data) != 8 {
+		return time.Time{}
+	}
+	nanos := binary.BigEndian.Uint64(data)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos))
+}
@@ -1,98 +1,103 @@
 package cache
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
-	"encoding/json"
-	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
 	"github.com/tmustier/economist-tui/internal/config"
 )
 
-const articleTTL = time.Hour
-
 const cacheDirName = "cache"
 
-type articleEntry struct {
-	CachedAt time.Time       `json:"cached_at"`
-	Article  article.Article `json:"article"`
+// Entry is a cached article along with the time it was stored and the time
+// it was last read.
+type Entry struct {
+	CachedAt time.Time
+	ReadAt   time.Time
+	Article  article.Article
 }
 
+// LoadArticle returns the cached article at url if it is within its TTL.
+// Expired entries are kept for offline reading until Maintain evicts them.
 func LoadArticle(url string) (*article.Article, bool, error) {
-	path := articleCachePath(url)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, false, nil
-		}
+	s := store()
+	entry, ok, err := s.Article(url)
+	if !ok || entry.Expired() {
 		return nil, false, err
 	}
 
-	var entry articleEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false, err
-	}
+	_ = s.TouchArticle(url, time.Now())
+	return &entry.Article, true, nil
+}
 
-	if time.Since(entry.CachedAt) > articleTTL {
-		_ = os.Remove(path)
-		return nil, false, nil
-	}
+// Lookup returns the cache entry for url even if it is past its TTL, so an
+// old copy can still be read offline. Callers that show the article should
+// call TouchArticle.
+func Lookup(url string) (*Entry, bool, error) {
+	return store().Article(url)
+}
 
-	return &entry.Article, true, nil
+// Expired reports whether the entry is past its TTL.
+func (e Entry) Expired() bool {
+	return time.Since(e.CachedAt) > CurrentSettings().ArticleTTL
 }
 
 func SaveArticle(art *article.Article) error {
-	if err := os.MkdirAll(cacheDir(), 0755); err != nil {
-		return err
-	}
-
-	entry := articleEntry{
-		CachedAt: time.Now().UTC(),
-		Article:  *art,
-	}
+	return store().PutArticle(Entry{CachedAt: time.Now().UTC(), Article: *art})
+}
 
-	data, err := json.Marshal(entry)
-	if err != nil {
-		return err
+// CachedAt reports when url was cached, if it is.
+func CachedAt(url string) (time.Time, bool) {
+	entry, ok, err := store().Article(url)
+	if err != nil || !ok {
+		return time.Time{}, false
 	}
+	return entry.CachedAt, true
+}
 
-	path := articleCachePath(art.URL)
-	return os.WriteFile(path, data, 0600)
+// TouchArticle records a read of the cached article at url. Eviction removes
+// the least recently read entries first.
+func TouchArticle(url string) {
+	_ = store().TouchArticle(url, time.Now())
 }
 
+// PurgeExpired runs Purge and reports only its error.
 func PurgeExpired() error {
-	dir := cacheDir()
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
-	}
+	_, err := Purge()
+	return err
+}
+
+// ListArticles returns every cached article, newest first, including entries
+// past their TTL that have not been purged yet.
+func ListArticles() ([]Entry, error) {
+	return store().Articles(Query{})
+}
+
+// FindArticles returns the cached articles matching q, newest first.
+func FindArticles(q Query) ([]Entry, error) {
+	return store().Articles(q)
+}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		path := filepath.Join(dir, entry.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
-			continue
-		}
-		var cached articleEntry
-		if err := json.Unmarshal(data, &cached); err != nil {
-			_ = os.Remove(path)
-			continue
-		}
-		if time.Since(cached.CachedAt) > articleTTL {
-			_ = os.Remove(path)
-		}
+// LoadFeed returns the cached RSS feed for section, whether or not it is past
+// its TTL.
+func LoadFeed(section string) (*FeedEntry, bool, error) {
+	s := store()
+	entry, ok, err := s.Feed(section)
+	if !ok {
+		return nil, false, err
 	}
+	_ = s.TouchFeed(section, time.Now())
+	return entry, true, nil
+}
 
-	return nil
+// SaveFeed caches an RSS feed. A zero CachedAt is set to now.
+func SaveFeed(entry FeedEntry) error {
+	if entry.CachedAt.IsZero() {
+		entry.CachedAt = time.Now().UTC()
+	}
+	return store().PutFeed(entry)
 }
 
 func CacheDir() string {
@@ -103,8 +108,14 @@ func cacheDir() string {
 	return filepath.Join(config.ConfigDir(), cacheDirName)
 }
 
-func articleCachePath(url string) string {
-	h := sha1.Sum([]byte(url))
-	name := hex.EncodeToString(h[:]) + ".json"
-	return filepath.Join(cacheDir(), name)
+// articleSection returns the section path of an article URL, which the bolt
+// backend indexes.
+func articleSection(url string) string {
+	return article.SectionFromURL(url)
+}
+
+func sortNewestFirst(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CachedAt.After(entries[j].CachedAt)
+	})
 }
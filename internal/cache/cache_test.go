@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,8 +66,41 @@ func TestLoadArticleExpires(t *testing.T) {
 	if ok {
 		t.Fatalf("expected cache miss for expired entry")
 	}
-	if _, err := os.Stat(path); !os.IsNotExist(err) {
-		t.Fatalf("expected expired cache file removed")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected expired cache file kept for offline reading: %v", err)
+	}
+}
+
+func TestLookupReturnsExpiredEntry(t *testing.T) {
+	setTempHome(t)
+	url := "https://example.com/expired"
+	writeEntry(t, url, time.Now().Add(-2*articleTTL))
+
+	stale, ok, err := Lookup(url)
+	if err != nil || !ok {
+		t.Fatalf("expected lookup hit, got %v %v", ok, err)
+	}
+	if stale.Article.URL != url || !stale.Expired() {
+		t.Fatalf("expected the expired entry, got %+v", stale)
+	}
+	if _, err := os.Stat(articleCachePath(url)); err != nil {
+		t.Fatalf("expected lookup to keep the expired entry: %v", err)
+	}
+}
+
+// writeEntry writes a cache entry for url as if it was cached at cachedAt.
+func writeEntry(t *testing.T, url string, cachedAt time.Time) {
+	t.Helper()
+	data, err := json.Marshal(articleEntry{CachedAt: cachedAt, Article: article.Article{URL: url}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	path := articleCachePath(url)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write: %v", err)
 	}
 }
 
@@ -105,3 +139,62 @@ func TestPurgeExpired(t *testing.T) {
 		t.Fatalf("expected fresh cache retained: %v", err)
 	}
 }
+
+func TestMaintainEvictsLeastRecentlyRead(t *testing.T) {
+	setTempHome(t)
+	t.Cleanup(func() { Configure(DefaultSettings()) })
+
+	urls := []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}
+	for i, url := range urls {
+		art := &article.Article{URL: url, Content: strings.Repeat("x", 1000)}
+		if err := SaveArticle(art); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+		old := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if err := os.Chtimes(articleCachePath(url), old, old); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	// Reading the oldest entry makes it the most recently used.
+	if _, ok, _ := LoadArticle(urls[0]); !ok {
+		t.Fatalf("expected cache hit")
+	}
+
+	// Entry sizes differ by a byte or two with the cached_at timestamp, so
+	// the limit is exactly what the two kept entries need.
+	var keep int64
+	for _, url := range []string{urls[0], urls[2]} {
+		info, err := os.Stat(articleCachePath(url))
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		keep += info.Size()
+	}
+	Configure(Settings{MaxBytes: keep})
+
+	result, err := Maintain()
+	if err != nil {
+		t.Fatalf("maintain: %v", err)
+	}
+	if result.Evicted != 1 || result.Expired != 0 {
+		t.Fatalf("expected one eviction, got %+v", result)
+	}
+	if _, err := os.Stat(articleCachePath(urls[1])); !os.IsNotExist(err) {
+		t.Fatalf("expected least recently read entry evicted")
+	}
+	for _, url := range []string{urls[0], urls[2]} {
+		if _, err := os.Stat(articleCachePath(url)); err != nil {
+			t.Fatalf("expected %s kept: %v", url, err)
+		}
+	}
+}
+
+func TestConfigureUsesDefaultsForZeroFields(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultSettings()) })
+
+	Configure(Settings{ArticleTTL: 3 * time.Hour})
+	got := CurrentSettings()
+	if got.ArticleTTL != 3*time.Hour || got.RSSTTL != rssTTL || got.StaleTTL != staleTTL || got.MaxBytes != defaultMaxBytes {
+		t.Fatalf("unexpected settings %+v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+)
+
+const (
+	lockFileName      = ".lock"
+	quarantineDirName = "quarantine"
+
+	// staleTempAge is how old a temp file must be before Maintain treats it
+	// as left behind by a crashed writer.
+	staleTempAge = time.Hour
+	// quarantineTTL is how long corrupt entries are kept for inspection.
+	quarantineTTL = 7 * 24 * time.Hour
+)
+
+// writeFile replaces path atomically. It holds a shared cache lock, so
+// Maintain does not remove entries while they are replaced.
+func writeFile(path string, data []byte) error {
+	unlock, err := lockShared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return atomicfile.Write(path, data)
+}
+
+// quarantine moves a corrupt cache file into the quarantine directory, where
+// it is kept for a week, instead of deleting it.
+func quarantine(path string) error {
+	dir := quarantineDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	now := time.Now()
+	dest := filepath.Join(dir, fmt.Sprintf("%s.%d", filepath.Base(path), now.UnixNano()))
+	if err := os.Rename(path, dest); err != nil {
+		return err
+	}
+	_ = os.Chtimes(dest, now, now)
+	return nil
+}
+
+// QuarantineDir returns the directory holding corrupt cache files.
+func QuarantineDir() string {
+	return quarantineDir()
+}
+
+func quarantineDir() string {
+	return filepath.Join(cacheDir(), quarantineDirName)
+}
+
+func lockPath() string {
+	return filepath.Join(cacheDir(), lockFileName)
+}
+
+// isEntryFile reports whether name is a JSON cache entry rather than the
+// lock file, an in-progress write or the bolt database.
+func isEntryFile(name string) bool {
+	return !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".json")
+}
+
+// lockShared takes the cache lock for a single write.
+func lockShared() (func(), error) {
+	return lockCache(false)
+}
+
+// lockExclusive takes the cache lock for a purge or bulk removal.
+func lockExclusive() (func(), error) {
+	return lockCache(true)
+}
+
+func lockCache(exclusive bool) (func(), error) {
+	unlock, err := atomicfile.LockFile(lockPath(), exclusive)
+	if err != nil {
+		return nil, fmt.Errorf("lock cache: %w", err)
+	}
+	return unlock, nil
+}
+
+// cleanLeftovers removes temp files from crashed writers and quarantined
+// files past their TTL.
+func cleanLeftovers() {
+	now := time.Now()
+	if entries, err := os.ReadDir(cacheDir()); err == nil {
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), atomicfile.TempPrefix) {
+				continue
+			}
+			if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > staleTempAge {
+				_ = os.Remove(filepath.Join(cacheDir(), entry.Name()))
+			}
+		}
+	}
+	if entries, err := os.ReadDir(quarantineDir()); err == nil {
+		for _, entry := range entries {
+			if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > quarantineTTL {
+				_ = os.Remove(filepath.Join(quarantineDir(), entry.Name()))
+			}
+		}
+	}
+}
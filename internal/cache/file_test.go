@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+)
+
+func TestConcurrentReadersNeverSeePartialJSON(t *testing.T) {
+	setTempHome(t)
+	url := "https://example.com/contended"
+	path := articleCachePath(url)
+
+	var writers, readers sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		writers.Add(1)
+		go func(i int) {
+			defer writers.Done()
+			for j := 0; j < 25; j++ {
+				// Alternate sizes so a torn write would leave trailing bytes.
+				size := 1000 + (i*25+j)%4*50000
+				art := &article.Article{URL: url, Content: strings.Repeat("x", size)}
+				if err := SaveArticle(art); err != nil {
+					t.Errorf("save: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	writers.Add(1)
+	go func() {
+		defer writers.Done()
+		for j := 0; j < 10; j++ {
+			if _, err := Maintain(); err != nil {
+				t.Errorf("maintain: %v", err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 8; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				data, err := os.ReadFile(path)
+				if os.IsNotExist(err) {
+					continue
+				}
+				if err != nil {
+					t.Errorf("read: %v", err)
+					return
+				}
+				if !json.Valid(data) {
+					t.Errorf("reader saw partial JSON (%d bytes)", len(data))
+					return
+				}
+			}
+		}()
+	}
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	if entries, err := os.ReadDir(quarantineDir()); err == nil && len(entries) > 0 {
+		t.Fatalf("expected nothing quarantined, got %d entries", len(entries))
+	}
+	if files, _ := os.ReadDir(cacheDir()); len(files) == 0 {
+		t.Fatalf("expected cache directory to hold the entry")
+	} else {
+		for _, file := range files {
+			if strings.HasPrefix(file.Name(), atomicfile.TempPrefix) {
+				t.Fatalf("expected no temp files left behind, found %s", file.Name())
+			}
+		}
+	}
+}
+
+func TestMaintainQuarantinesCorruptEntries(t *testing.T) {
+	setTempHome(t)
+	url := "https://example.com/corrupt"
+	path := articleCachePath(url)
+	if err := writeFile(path, []byte(`{"cached_at":"2026-`)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	result, err := Maintain()
+	if err != nil {
+		t.Fatalf("maintain: %v", err)
+	}
+	if result.Quarantined != 1 {
+		t.Fatalf("expected one quarantined entry, got %+v", result)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected corrupt entry moved out of the cache")
+	}
+	entries, err := os.ReadDir(quarantineDir())
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected corrupt entry in quarantine, got %d (%v)", len(entries), err)
+	}
+
+	stale := time.Now().Add(-2 * quarantineTTL)
+	quarantined := filepath.Join(quarantineDir(), entries[0].Name())
+	if err := os.Chtimes(quarantined, stale, stale); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if _, err := Maintain(); err != nil {
+		t.Fatalf("maintain: %v", err)
+	}
+	if _, err := os.Stat(quarantined); !os.IsNotExist(err) {
+		t.Fatalf("expected old quarantined entry removed")
+	}
+}
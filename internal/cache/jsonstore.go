@@ -0,0 +1,392 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// jsonStore keeps one JSON file per entry in the cache directory, named by
+// the SHA1 of the article URL or, with FeedPrefix, of the feed section. File
+// modification times record when an entry was last read.
+type jsonStore struct{}
+
+type articleEntry struct {
+	CachedAt time.Time       `json:"cached_at"`
+	Article  article.Article `json:"article"`
+}
+
+type feedEntry struct {
+	Section      string    `json:"section,omitempty"`
+	CachedAt     time.Time `json:"cached_at"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+}
+
+func newFeedEntry(entry FeedEntry) feedEntry {
+	return feedEntry{
+		Section:      entry.Section,
+		CachedAt:     entry.CachedAt,
+		Body:         entry.Body,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+	}
+}
+
+func (e feedEntry) toFeedEntry(section string) *FeedEntry {
+	return &FeedEntry{
+		Section:      section,
+		CachedAt:     e.CachedAt,
+		Body:         e.Body,
+		ETag:         e.ETag,
+		LastModified: e.LastModified,
+	}
+}
+
+func (jsonStore) Article(url string) (*Entry, bool, error) {
+	path := articleCachePath(url)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var cached articleEntry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		_ = quarantine(path)
+		return nil, false, err
+	}
+	return &Entry{CachedAt: cached.CachedAt, Article: cached.Article, ReadAt: modTime(path)}, true, nil
+}
+
+func (jsonStore) PutArticle(entry Entry) error {
+	data, err := json.Marshal(articleEntry{CachedAt: entry.CachedAt, Article: entry.Article})
+	if err != nil {
+		return err
+	}
+	return writeFile(articleCachePath(entry.Article.URL), data)
+}
+
+func (jsonStore) DeleteArticle(url string) error {
+	err := os.Remove(articleCachePath(url))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (jsonStore) DeleteArticles(urls []string) (int, error) {
+	removed := 0
+	for _, url := range urls {
+		if err := os.Remove(articleCachePath(url)); err == nil {
+			removed++
+		} else if !os.IsNotExist(err) {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+func (s jsonStore) Articles(q Query) ([]Entry, error) {
+	var entries []Entry
+	if len(q.URLs) > 0 {
+		for _, url := range q.URLs {
+			if entry, ok, err := s.Article(url); err == nil && ok && q.matches(*entry) {
+				entries = append(entries, *entry)
+			}
+		}
+		sortNewestFirst(entries)
+		return entries, nil
+	}
+	err := s.walkArticles(func(path string, entry Entry) {
+		if q.matches(entry) {
+			entries = append(entries, entry)
+		}
+	})
+	sortNewestFirst(entries)
+	return entries, err
+}
+
+func (jsonStore) TouchArticle(url string, at time.Time) error {
+	return os.Chtimes(articleCachePath(url), at, at)
+}
+
+func (jsonStore) Feed(section string) (*FeedEntry, bool, error) {
+	path := feedCachePath(section)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var cached feedEntry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		_ = quarantine(path)
+		return nil, false, err
+	}
+	return cached.toFeedEntry(section), true, nil
+}
+
+func (jsonStore) PutFeed(entry FeedEntry) error {
+	data, err := json.Marshal(newFeedEntry(entry))
+	if err != nil {
+		return err
+	}
+	return writeFile(feedCachePath(entry.Section), data)
+}
+
+func (jsonStore) TouchFeed(section string, at time.Time) error {
+	return os.Chtimes(feedCachePath(section), at, at)
+}
+
+func (jsonStore) DeleteFeeds(cutoff time.Time) (int, error) {
+	files, err := readCacheDir()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range files {
+		if !isFeedFile(file.Name()) {
+			continue
+		}
+		path := filepath.Join(cacheDir(), file.Name())
+		if !cutoff.IsZero() {
+			var cached feedEntry
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if json.Unmarshal(data, &cached) == nil && !cached.CachedAt.Before(cutoff) {
+				continue
+			}
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (jsonStore) Maintain(settings Settings) (MaintainResult, error) {
+	var result MaintainResult
+	cleanLeftovers()
+	files, err := readCacheDir()
+	if err != nil {
+		return result, err
+	}
+
+	type cacheFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	articleCutoff, feedCutoff := settings.cutoffs(time.Now())
+	var kept []cacheFile
+	for _, file := range files {
+		path := filepath.Join(cacheDir(), file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var cached struct {
+			CachedAt time.Time `json:"cached_at"`
+		}
+		if err := json.Unmarshal(data, &cached); err != nil {
+			if quarantine(path) == nil {
+				result.Quarantined++
+			}
+			continue
+		}
+		cutoff := articleCutoff
+		if isFeedFile(file.Name()) {
+			cutoff = feedCutoff
+		}
+		if cached.CachedAt.Before(cutoff) {
+			if os.Remove(path) == nil {
+				result.Expired++
+			}
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		kept = append(kept, cacheFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		result.Bytes += info.Size()
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].modTime.Before(kept[j].modTime)
+	})
+	for _, file := range kept {
+		if result.Bytes <= settings.MaxBytes {
+			break
+		}
+		if os.Remove(file.path) == nil {
+			result.Evicted++
+			result.Bytes -= file.size
+		}
+	}
+	return result, nil
+}
+
+func (jsonStore) Stats() (Usage, error) {
+	usage := Usage{Backend: BackendJSON, Path: cacheDir()}
+	files, err := readCacheDir()
+	if err != nil {
+		return usage, err
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		if isFeedFile(file.Name()) {
+			usage.Feeds++
+			usage.FeedBytes += info.Size()
+		} else {
+			usage.Articles++
+			usage.ArticleBytes += info.Size()
+		}
+	}
+	if quarantined, err := os.ReadDir(quarantineDir()); err == nil {
+		usage.Quarantined = len(quarantined)
+	}
+	return usage, nil
+}
+
+// walkArticles calls fn for every readable article in the cache directory.
+func (jsonStore) walkArticles(fn func(path string, entry Entry)) error {
+	files, err := readCacheDir()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if isFeedFile(file.Name()) {
+			continue
+		}
+		path := filepath.Join(cacheDir(), file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var cached articleEntry
+		if err := json.Unmarshal(data, &cached); err != nil || cached.Article.URL == "" {
+			continue
+		}
+		var readAt time.Time
+		if info, err := file.Info(); err == nil {
+			readAt = info.ModTime()
+		}
+		fn(path, Entry{CachedAt: cached.CachedAt, Article: cached.Article, ReadAt: readAt})
+	}
+	return nil
+}
+
+type feedFile struct {
+	FeedEntry
+	path   string
+	readAt time.Time
+}
+
+// feeds returns the cached feeds whose section is recorded in the file or is
+// one of sections. Files written before sections were recorded only carry a
+// hash of the section.
+func (jsonStore) feeds(sections []string) ([]feedFile, error) {
+	byPath := make(map[string]string, len(sections))
+	for _, section := range sections {
+		byPath[feedCachePath(section)] = section
+	}
+
+	files, err := readCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	var feeds []feedFile
+	for _, file := range files {
+		if !isFeedFile(file.Name()) {
+			continue
+		}
+		path := filepath.Join(cacheDir(), file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var cached feedEntry
+		if err := json.Unmarshal(data, &cached); err != nil {
+			continue
+		}
+		section := cached.Section
+		if section == "" {
+			section = byPath[path]
+		}
+		if section == "" {
+			continue
+		}
+		feeds = append(feeds, feedFile{
+			FeedEntry: *cached.toFeedEntry(section),
+			path:      path,
+			readAt:    modTime(path),
+		})
+	}
+	return feeds, nil
+}
+
+// readCacheDir lists the entry files in the cache directory, leaving out the
+// lock file, in-progress writes, the quarantine directory and the bolt
+// database.
+func readCacheDir() ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(cacheDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	files := entries[:0]
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && isEntryFile(entry.Name()) {
+			files = append(files, entry)
+		}
+	}
+	return files, nil
+}
+
+func isFeedFile(name string) bool {
+	return strings.HasPrefix(name, FeedPrefix)
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func articleCachePath(url string) string {
+	return filepath.Join(cacheDir(), articleFileName(url))
+}
+
+func articleFileName(url string) string {
+	h := sha1.Sum([]byte(url))
+	return hex.EncodeToString(h[:]) + ".json"
+}
+
+func feedCachePath(section string) string {
+	h := sha1.Sum([]byte(section))
+	return filepath.Join(cacheDir(), FeedPrefix+hex.EncodeToString(h[:])+".json")
+}
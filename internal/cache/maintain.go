@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"os"
+	"time"
+)
+
+// MaintainResult reports what Maintain removed and what is left.
+type MaintainResult struct {
+	Expired     int
+	Evicted     int
+	Quarantined int
+	Bytes       int64
+}
+
+// Maintain removes entries that have been past their TTL for longer than
+// StaleTTL, quarantines corrupt ones, then evicts the least recently read
+// entries until the cache fits in the configured size. Entries past their
+// TTL but within StaleTTL stay for offline reads. It holds the cache lock
+// exclusively, so it never runs alongside another purge or a write.
+func Maintain() (MaintainResult, error) {
+	return maintain(CurrentSettings())
+}
+
+// Purge is Maintain without the stale retention: it removes every entry past
+// its TTL.
+func Purge() (MaintainResult, error) {
+	settings := CurrentSettings()
+	settings.StaleTTL = 0
+	return maintain(settings)
+}
+
+func maintain(settings Settings) (MaintainResult, error) {
+	if _, err := os.Stat(cacheDir()); os.IsNotExist(err) {
+		return MaintainResult{}, nil
+	}
+	unlock, err := lockExclusive()
+	if err != nil {
+		return MaintainResult{}, err
+	}
+	defer unlock()
+	return store().Maintain(settings)
+}
+
+// cutoffs returns the fetch times before which articles and feeds are past
+// their TTL plus the stale retention.
+func (s Settings) cutoffs(now time.Time) (articles, feeds time.Time) {
+	return now.Add(-s.ArticleTTL - s.StaleTTL), now.Add(-s.RSSTTL - s.StaleTTL)
+}
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// FeedPrefix starts the file names of cached RSS feeds in the JSON backend.
+// Every other file in the cache directory holds an article.
+const FeedPrefix = "rss-"
+
+// maxImportEntry caps the size of one article read from an import archive.
+const maxImportEntry = 16 << 20
+
+// Usage counts cache entries and their sizes.
+type Usage struct {
+	Backend      string
+	Path         string
+	Articles     int
+	ArticleBytes int64
+	Feeds        int
+	FeedBytes    int64
+	Quarantined  int
+}
+
+// Stats reports how many articles and feeds are cached and how much space
+// they take.
+func Stats() (Usage, error) {
+	return store().Stats()
+}
+
+// RemoveArticles deletes the cached articles for which match returns true,
+// or every cached article when match is nil, and returns how many it removed.
+func RemoveArticles(match func(Entry) bool) (int, error) {
+	unlock, err := lockExclusive()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	s := store()
+	entries, err := s.Articles(Query{})
+	if err != nil {
+		return 0, err
+	}
+	var urls []string
+	for _, entry := range entries {
+		if match == nil || match(entry) {
+			urls = append(urls, entry.Article.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return 0, nil
+	}
+	return s.DeleteArticles(urls)
+}
+
+// RemoveFeeds deletes cached feeds stored before cutoff, or every cached feed
+// when cutoff is zero, and returns how many it removed.
+func RemoveFeeds(cutoff time.Time) (int, error) {
+	unlock, err := lockExclusive()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	return store().DeleteFeeds(cutoff)
+}
+
+// Export writes every cached article to w as a gzipped tar archive and
+// returns how many it wrote. Feeds are left out: they go stale in minutes.
+func Export(w io.Writer) (int, error) {
+	entries, err := store().Articles(Query{})
+	if err != nil {
+		return 0, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	written := 0
+	for _, entry := range entries {
+		data, err := json.Marshal(articleEntry{CachedAt: entry.CachedAt, Article: entry.Article})
+		if err != nil {
+			return written, err
+		}
+		header := &tar.Header{
+			Name:    articleFileName(entry.Article.URL),
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: entry.ReadAt,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return written, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	if err := tw.Close(); err != nil {
+		return written, err
+	}
+	return written, gz.Close()
+}
+
+// Import reads an archive written by Export and caches its articles. An entry
+// is skipped when the cache already holds a copy of the same age or newer.
+// It returns how many articles were imported.
+func Import(r io.Reader) (int, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("read archive: %w", err)
+	}
+	defer gz.Close()
+
+	s := store()
+	imported := 0
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, fmt.Errorf("read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxImportEntry || isFeedFile(header.Name) {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxImportEntry))
+		if err != nil {
+			return imported, fmt.Errorf("read archive: %w", err)
+		}
+		var cached articleEntry
+		if err := json.Unmarshal(data, &cached); err != nil || cached.Article.URL == "" {
+			continue
+		}
+		if existing, ok := CachedAt(cached.Article.URL); ok && !existing.Before(cached.CachedAt) {
+			continue
+		}
+
+		// Entries are stored under their URL rather than the archive's file
+		// name, so an entry cannot be written outside the cache.
+		if err := s.PutArticle(Entry{CachedAt: cached.CachedAt, Article: cached.Article}); err != nil {
+			return imported, err
+		}
+		if !header.ModTime.IsZero() {
+			_ = s.TouchArticle(cached.Article.URL, header.ModTime)
+		}
+		imported++
+	}
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestStatsSplitsFeedsAndArticles(t *testing.T) {
+	setTempHome(t)
+	if err := SaveArticle(&article.Article{URL: "https://example.com/a", Title: "A"}); err != nil {
+		t.Fatalf("save article: %v", err)
+	}
+	feed := filepath.Join(cacheDir(), FeedPrefix+"leaders.json")
+	if err := os.WriteFile(feed, []byte(`{"cached_at":"2026-01-01T00:00:00Z","body":""}`), 0600); err != nil {
+		t.Fatalf("write feed: %v", err)
+	}
+
+	usage, err := Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if usage.Articles != 1 || usage.Feeds != 1 || usage.ArticleBytes == 0 || usage.FeedBytes == 0 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	entries, err := ListArticles()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected feeds to be left out of ListArticles, got %d entries (%v)", len(entries), err)
+	}
+}
+
+func TestRemoveArticlesMatchesFilter(t *testing.T) {
+	setTempHome(t)
+	for _, url := range []string{"https://example.com/keep", "https://example.com/drop"} {
+		if err := SaveArticle(&article.Article{URL: url}); err != nil {
+			t.Fatalf("save article: %v", err)
+		}
+	}
+
+	removed, err := RemoveArticles(func(entry Entry) bool {
+		return entry.Article.URL == "https://example.com/drop"
+	})
+	if err != nil || removed != 1 {
+		t.Fatalf("expected one article removed, got %d (%v)", removed, err)
+	}
+	if _, ok, _ := Lookup("https://example.com/keep"); !ok {
+		t.Fatalf("expected unmatched article to stay cached")
+	}
+	if _, ok, _ := Lookup("https://example.com/drop"); ok {
+		t.Fatalf("expected matched article to be removed")
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	setTempHome(t)
+	art := &article.Article{URL: "https://example.com/moved", Title: "Moved", Content: "Body"}
+	if err := SaveArticle(art); err != nil {
+		t.Fatalf("save article: %v", err)
+	}
+
+	var archive bytes.Buffer
+	if n, err := Export(&archive); err != nil || n != 1 {
+		t.Fatalf("expected one article exported, got %d (%v)", n, err)
+	}
+
+	setTempHome(t)
+	if n, err := Import(bytes.NewReader(archive.Bytes())); err != nil || n != 1 {
+		t.Fatalf("expected one article imported, got %d (%v)", n, err)
+	}
+	entry, ok, err := Lookup(art.URL)
+	if err != nil || !ok || entry.Article.Content != art.Content {
+		t.Fatalf("expected imported article, got %+v ok=%v err=%v", entry, ok, err)
+	}
+
+	if n, err := Import(bytes.NewReader(archive.Bytes())); err != nil || n != 0 {
+		t.Fatalf("expected an entry of the same age to be skipped, got %d (%v)", n, err)
+	}
+}
+
+func TestRemoveFeedsBeforeCutoff(t *testing.T) {
+	setTempHome(t)
+	if err := os.MkdirAll(cacheDir(), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	old := filepath.Join(cacheDir(), FeedPrefix+"old.json")
+	fresh := filepath.Join(cacheDir(), FeedPrefix+"fresh.json")
+	_ = os.WriteFile(old, []byte(`{"cached_at":"2020-01-01T00:00:00Z"}`), 0600)
+	_ = os.WriteFile(fresh, []byte(`{"cached_at":"`+time.Now().UTC().Format(time.RFC3339)+`"}`), 0600)
+
+	removed, err := RemoveFeeds(time.Now().Add(-time.Hour))
+	if err != nil || removed != 1 {
+		t.Fatalf("expected one feed removed, got %d (%v)", removed, err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("expected fresh feed to stay: %v", err)
+	}
+}
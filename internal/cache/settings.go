@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	articleTTL      = time.Hour
+	rssTTL          = 120 * time.Second
+	defaultMaxBytes = 200 << 20
+	staleTTL        = 30 * 24 * time.Hour
+)
+
+// Settings control how long entries stay fresh, how large the cache may grow
+// and where it is stored. Zero fields use the defaults.
+type Settings struct {
+	ArticleTTL time.Duration
+	RSSTTL     time.Duration
+	// StaleTTL is how long entries are kept past their TTL, for offline
+	// reads, before maintenance removes them.
+	StaleTTL time.Duration
+	MaxBytes int64
+	Backend  string
+}
+
+var (
+	settingsMu sync.RWMutex
+	settings   = DefaultSettings()
+)
+
+// DefaultSettings returns a 1h article TTL, a 2m feed TTL, 30 days of stale
+// retention, a 200MB limit and the JSON-file backend.
+func DefaultSettings() Settings {
+	return Settings{ArticleTTL: articleTTL, RSSTTL: rssTTL, StaleTTL: staleTTL, MaxBytes: defaultMaxBytes, Backend: BackendJSON}
+}
+
+// Configure replaces the cache settings for this process.
+func Configure(s Settings) {
+	defaults := DefaultSettings()
+	if s.ArticleTTL <= 0 {
+		s.ArticleTTL = defaults.ArticleTTL
+	}
+	if s.RSSTTL <= 0 {
+		s.RSSTTL = defaults.RSSTTL
+	}
+	if s.StaleTTL <= 0 {
+		s.StaleTTL = defaults.StaleTTL
+	}
+	if s.MaxBytes <= 0 {
+		s.MaxBytes = defaults.MaxBytes
+	}
+	if s.Backend == "" {
+		s.Backend = defaults.Backend
+	}
+
+	settingsMu.Lock()
+	settings = s
+	settingsMu.Unlock()
+}
+
+// CurrentSettings returns the settings in effect.
+func CurrentSettings() Settings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return settings
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"os"
+	"time"
+)
+
+// Cache backends, selected with Settings.Backend.
+const (
+	BackendJSON = "json"
+	BackendBolt = "bolt"
+)
+
+// Store persists cached articles and feeds. Implementations must be safe to
+// use from several processes at once.
+type Store interface {
+	// Article returns the entry for url, whether or not it is past its TTL.
+	Article(url string) (*Entry, bool, error)
+	PutArticle(entry Entry) error
+	DeleteArticle(url string) error
+	// DeleteArticles removes the entries for urls in one operation and
+	// returns how many it removed.
+	DeleteArticles(urls []string) (int, error)
+	// Articles returns the entries matching q, newest first.
+	Articles(q Query) ([]Entry, error)
+	TouchArticle(url string, at time.Time) error
+
+	Feed(section string) (*FeedEntry, bool, error)
+	PutFeed(entry FeedEntry) error
+	TouchFeed(section string, at time.Time) error
+	// DeleteFeeds removes feeds cached before cutoff, or every feed when
+	// cutoff is zero.
+	DeleteFeeds(cutoff time.Time) (int, error)
+
+	// Maintain removes entries past their TTL plus StaleTTL, then evicts the
+	// least recently read until the cache fits in MaxBytes.
+	Maintain(s Settings) (MaintainResult, error)
+	Stats() (Usage, error)
+}
+
+// Query selects cached articles. Zero fields match everything; After and
+// Before compare against the time the article was cached. URLs, when set,
+// looks those articles up directly instead of scanning the cache.
+type Query struct {
+	Section string
+	After   time.Time
+	Before  time.Time
+	URLs    []string
+}
+
+func (q Query) matches(entry Entry) bool {
+	if q.Section != "" && articleSection(entry.Article.URL) != q.Section {
+		return false
+	}
+	if !q.After.IsZero() && !entry.CachedAt.After(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !entry.CachedAt.Before(q.Before) {
+		return false
+	}
+	return true
+}
+
+// FeedEntry is a cached RSS feed body, with the validators the server sent
+// so the next request can be conditional.
+type FeedEntry struct {
+	Section      string
+	CachedAt     time.Time
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+// store returns the backend selected in the current settings.
+func store() Store {
+	if CurrentSettings().Backend == BackendBolt {
+		return newBoltStore(boltPath())
+	}
+	return jsonStore{}
+}
+
+// MigrateResult counts the entries Migrate moved.
+type MigrateResult struct {
+	Articles int
+	Feeds    int
+}
+
+// Migrate moves the JSON cache files into the configured backend and deletes
+// them. Old feed files do not record their section, so they are matched
+// against sections; any that do not match are left in place. It does nothing
+// when the JSON backend is in use.
+func Migrate(sections []string) (MigrateResult, error) {
+	var result MigrateResult
+	dest := store()
+	if _, ok := dest.(jsonStore); ok {
+		return result, nil
+	}
+
+	var src jsonStore
+	articles, err := src.Articles(Query{})
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range articles {
+		if existing, ok, err := dest.Article(entry.Article.URL); err == nil && ok && !existing.CachedAt.Before(entry.CachedAt) {
+			_ = src.DeleteArticle(entry.Article.URL)
+			continue
+		}
+		if err := dest.PutArticle(entry); err != nil {
+			return result, err
+		}
+		_ = dest.TouchArticle(entry.Article.URL, entry.ReadAt)
+		_ = src.DeleteArticle(entry.Article.URL)
+		result.Articles++
+	}
+
+	feeds, err := src.feeds(sections)
+	if err != nil {
+		return result, err
+	}
+	for _, feed := range feeds {
+		if err := dest.PutFeed(feed.FeedEntry); err != nil {
+			return result, err
+		}
+		_ = dest.TouchFeed(feed.Section, feed.readAt)
+		_ = os.Remove(feed.path)
+		result.Feeds++
+	}
+	return result, nil
+}
@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func useBackend(t *testing.T, backend string) {
+	t.Helper()
+	setTempHome(t)
+	Configure(Settings{Backend: backend})
+	t.Cleanup(func() { Configure(DefaultSettings()) })
+}
+
+func TestStoresQueryBySectionAndTime(t *testing.T) {
+	for _, backend := range []string{BackendJSON, BackendBolt} {
+		t.Run(backend, func(t *testing.T) {
+			useBackend(t, backend)
+			s := store()
+			now := time.Now().UTC()
+			entries := []Entry{
+				{CachedAt: now.Add(-3 * time.Hour), Article: article.Article{URL: "https://www.economist.com/leaders/2026/01/01/a", Title: "A"}},
+				{CachedAt: now.Add(-2 * time.Hour), Article: article.Article{URL: "https://www.economist.com/finance-and-economics/2026/01/01/b", Title: "B"}},
+				{CachedAt: now.Add(-time.Hour), Article: article.Article{URL: "https://www.economist.com/leaders/2026/01/02/c", Title: "C"}},
+			}
+			for _, entry := range entries {
+				if err := s.PutArticle(entry); err != nil {
+					t.Fatalf("put: %v", err)
+				}
+			}
+
+			got, ok, err := s.Article(entries[1].Article.URL)
+			if err != nil || !ok || got.Article.Title != "B" || !got.CachedAt.Equal(entries[1].CachedAt) {
+				t.Fatalf("expected entry B, got %+v ok=%v err=%v", got, ok, err)
+			}
+
+			leaders, err := s.Articles(Query{Section: "leaders"})
+			if err != nil || len(leaders) != 2 || leaders[0].Article.Title != "C" || leaders[1].Article.Title != "A" {
+				t.Fatalf("expected leaders C, A newest first, got %v (%v)", titles(leaders), err)
+			}
+
+			older, err := s.Articles(Query{Before: now.Add(-90 * time.Minute)})
+			if err != nil || len(older) != 2 || older[0].Article.Title != "B" {
+				t.Fatalf("expected B and A before the cutoff, got %v (%v)", titles(older), err)
+			}
+
+			if err := s.PutArticle(Entry{CachedAt: now, Article: entries[0].Article}); err != nil {
+				t.Fatalf("replace: %v", err)
+			}
+			older, _ = s.Articles(Query{Before: now.Add(-90 * time.Minute)})
+			if len(older) != 1 || older[0].Article.Title != "B" {
+				t.Fatalf("expected the replaced entry to move in the time index, got %v", titles(older))
+			}
+
+			if err := s.DeleteArticle(entries[1].Article.URL); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if all, _ := s.Articles(Query{}); len(all) != 2 {
+				t.Fatalf("expected 2 articles after delete, got %v", titles(all))
+			}
+
+			byURL, err := s.Articles(Query{URLs: []string{entries[2].Article.URL, entries[1].Article.URL, "https://example.com/missing"}})
+			if err != nil || len(byURL) != 1 || byURL[0].Article.Title != "C" {
+				t.Fatalf("expected only C by URL, got %v (%v)", titles(byURL), err)
+			}
+			n, err := s.DeleteArticles([]string{entries[0].Article.URL, entries[1].Article.URL, entries[2].Article.URL})
+			if err != nil || n != 2 {
+				t.Fatalf("expected 2 articles removed in a batch, got %d (%v)", n, err)
+			}
+			if all, _ := s.Articles(Query{}); len(all) != 0 {
+				t.Fatalf("expected no articles after batch delete, got %v", titles(all))
+			}
+		})
+	}
+}
+
+func TestStoresMaintainAndFeeds(t *testing.T) {
+	for _, backend := range []string{BackendJSON, BackendBolt} {
+		t.Run(backend, func(t *testing.T) {
+			useBackend(t, backend)
+			if err := SaveFeed(FeedEntry{Section: "leaders", Body: []byte("<rss/>"), ETag: `"v1"`}); err != nil {
+				t.Fatalf("save feed: %v", err)
+			}
+			feed, ok, err := LoadFeed("leaders")
+			if err != nil || !ok || string(feed.Body) != "<rss/>" || feed.ETag != `"v1"` {
+				t.Fatalf("expected cached feed, got %+v ok=%v err=%v", feed, ok, err)
+			}
+
+			oldFeed := FeedEntry{Section: "asia", CachedAt: time.Now().Add(-staleTTL - 30*time.Minute), Body: []byte("<rss/>")}
+			if err := SaveFeed(oldFeed); err != nil {
+				t.Fatalf("save feed: %v", err)
+			}
+			gone := Entry{CachedAt: time.Now().Add(-articleTTL - staleTTL - time.Hour), Article: article.Article{URL: "https://example.com/gone"}}
+			stale := Entry{CachedAt: time.Now().Add(-2 * articleTTL), Article: article.Article{URL: "https://example.com/stale"}}
+			for _, entry := range []Entry{gone, stale} {
+				if err := store().PutArticle(entry); err != nil {
+					t.Fatalf("put: %v", err)
+				}
+			}
+			if err := SaveArticle(&article.Article{URL: "https://example.com/fresh"}); err != nil {
+				t.Fatalf("save: %v", err)
+			}
+
+			result, err := Maintain()
+			if err != nil || result.Expired != 2 || result.Evicted != 0 {
+				t.Fatalf("expected the old article and feed expired, got %+v (%v)", result, err)
+			}
+			if _, ok, _ := LoadFeed("asia"); ok {
+				t.Fatalf("expected the feed to expire by the feed TTL")
+			}
+			if got, ok, err := Lookup(stale.Article.URL); err != nil || !ok || !got.Expired() {
+				t.Fatalf("expected the stale entry kept for offline reads, got ok=%v err=%v", ok, err)
+			}
+			usage, err := Stats()
+			if err != nil || usage.Backend != backend || usage.Articles != 2 || usage.Feeds != 1 {
+				t.Fatalf("unexpected usage %+v (%v)", usage, err)
+			}
+
+			result, err = Purge()
+			if err != nil || result.Expired != 1 {
+				t.Fatalf("expected purge to remove the stale entry, got %+v (%v)", result, err)
+			}
+			if _, ok, _ := Lookup(stale.Article.URL); ok {
+				t.Fatalf("expected the stale entry purged")
+			}
+
+			if n, err := RemoveFeeds(time.Time{}); err != nil || n != 1 {
+				t.Fatalf("expected one feed removed, got %d (%v)", n, err)
+			}
+		})
+	}
+}
+
+func TestMigrateMovesJSONFilesIntoBolt(t *testing.T) {
+	setTempHome(t)
+	t.Cleanup(func() { Configure(DefaultSettings()) })
+
+	if err := SaveArticle(&article.Article{URL: "https://www.economist.com/leaders/2026/01/01/a", Title: "A"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := SaveFeed(FeedEntry{Section: "leaders", Body: []byte("<rss/>")}); err != nil {
+		t.Fatalf("save feed: %v", err)
+	}
+	// Feed files written before sections were recorded.
+	legacy, _ := json.Marshal(struct {
+		CachedAt time.Time `json:"cached_at"`
+		Body     []byte    `json:"body"`
+	}{time.Now().UTC(), []byte("<rss>old</rss>")})
+	if err := os.WriteFile(feedCachePath("britain"), legacy, 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	Configure(Settings{Backend: BackendBolt})
+	result, err := Migrate([]string{"britain"})
+	if err != nil || result.Articles != 1 || result.Feeds != 2 {
+		t.Fatalf("expected 1 article and 2 feeds migrated, got %+v (%v)", result, err)
+	}
+	if files, _ := readCacheDir(); len(files) != 0 {
+		t.Fatalf("expected JSON files removed after migration, %d left", len(files))
+	}
+	if entry, ok, err := Lookup("https://www.economist.com/leaders/2026/01/01/a"); err != nil || !ok || entry.Article.Title != "A" {
+		t.Fatalf("expected migrated article, got %+v ok=%v err=%v", entry, ok, err)
+	}
+	if feed, ok, _ := LoadFeed("britain"); !ok || string(feed.Body) != "<rss>old</rss>" {
+		t.Fatalf("expected legacy feed migrated, got %+v", feed)
+	}
+}
+
+func titles(entries []Entry) []string {
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, entry.Article.Title)
+	}
+	return out
+}
@@ -8,6 +8,19 @@ import (
 
 type Config struct {
 	Cookies []Cookie `json:"cookies"`
+	// FetchWorkers is how many articles the daemon fetches at once.
+	FetchWorkers int         `json:"fetch_workers,omitempty"`
+	Cache        CacheConfig `json:"cache"`
+}
+
+// CacheConfig holds cache limits and the storage backend. TTLs are Go
+// durations such as "1h" or "90s"; empty values use the defaults.
+type CacheConfig struct {
+	ArticleTTL string `json:"article_ttl,omitempty"`
+	RSSTTL     string `json:"rss_ttl,omitempty"`
+	MaxSizeMB  int    `json:"max_size_mb,omitempty"`
+	// Backend is "json" (one file per entry, the default) or "bolt".
+	Backend string `json:"backend,omitempty"`
 }
 
 type Cookie struct {
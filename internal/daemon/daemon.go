@@ -11,19 +11,28 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
 	"github.com/tmustier/economist-tui/internal/config"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/library"
 	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
+	"github.com/tmustier/economist-tui/internal/rss"
 )
 
 const (
 	socketName = "serve.sock"
 	logName    = "serve.log"
+
+	maintenanceInterval = 15 * time.Minute
+
+	// DefaultFeedInterval is how often the daemon refreshes every section
+	// feed when no interval is given.
+	DefaultFeedInterval = 10 * time.Minute
 )
 
 var ErrNotRunning = errors.New("economist serve not running")
@@ -41,6 +50,16 @@ type FetchRequest struct {
 	Debug bool   `json:"debug"`
 }
 
+// PrefetchRequest asks the daemon to fetch articles into the cache in the
+// background.
+type PrefetchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+type PrefetchResponse struct {
+	Queued int `json:"queued"`
+}
+
 type FetchResponse struct {
 	Article   *ArticlePayload `json:"article,omitempty"`
 	Error     string          `json:"error,omitempty"`
@@ -48,13 +67,18 @@ type FetchResponse struct {
 }
 
 type ArticlePayload struct {
-	Overtitle     string `json:"overtitle,omitempty"`
-	Title         string `json:"title"`
-	Subtitle      string `json:"subtitle,omitempty"`
-	DateLine      string `json:"date_line,omitempty"`
-	Content       string `json:"content,omitempty"`
-	URL           string `json:"url"`
-	DebugHTMLPath string `json:"debug_html_path,omitempty"`
+	Overtitle     string          `json:"overtitle,omitempty"`
+	Title         string          `json:"title"`
+	Subtitle      string          `json:"subtitle,omitempty"`
+	DateLine      string          `json:"date_line,omitempty"`
+	Authors       []string        `json:"authors,omitempty"`
+	Published     time.Time       `json:"published,omitzero"`
+	Section       string          `json:"section,omitempty"`
+	WordCount     int             `json:"word_count,omitempty"`
+	Content       string          `json:"content,omitempty"`
+	Blocks        []article.Block `json:"blocks,omitempty"`
+	URL           string          `json:"url"`
+	DebugHTMLPath string          `json:"debug_html_path,omitempty"`
 }
 
 func IsRunning() bool {
@@ -206,14 +230,63 @@ func Fetch(ctx context.Context, url string, debug bool) (*article.Article, error
 		Title:         payload.Article.Title,
 		Subtitle:      payload.Article.Subtitle,
 		DateLine:      payload.Article.DateLine,
+		Authors:       payload.Article.Authors,
+		Published:     payload.Article.Published,
+		Section:       payload.Article.Section,
+		WordCount:     payload.Article.WordCount,
 		Content:       payload.Article.Content,
+		Blocks:        payload.Article.Blocks,
 		URL:           payload.Article.URL,
 		DebugHTMLPath: payload.Article.DebugHTMLPath,
 	}
 	return art, nil
 }
 
-func Serve() error {
+// ServeOptions configures the daemon.
+type ServeOptions struct {
+	// Workers is the number of browser tabs fetching at once. Zero uses
+	// DefaultPoolSize.
+	Workers int
+	// FeedInterval is how often every section feed is refreshed into the
+	// cache. Zero turns scheduled refreshes off.
+	FeedInterval time.Duration
+	// Debug logs background failures, such as prefetches, to stderr.
+	Debug bool
+}
+
+// Prefetch queues urls to be fetched into the cache while the daemon is idle.
+func Prefetch(ctx context.Context, urls []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	reqBody, err := json.Marshal(PrefetchRequest{URLs: urls})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://unix/prefetch", bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if isConnRefused(err) {
+			return ErrNotRunning
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func Serve(opts ServeOptions) error {
 	if err := os.MkdirAll(config.ConfigDir(), 0755); err != nil {
 		return err
 	}
@@ -233,7 +306,8 @@ func Serve() error {
 	}()
 
 	_ = os.Chmod(socketPath, 0600)
-	fmt.Printf("Daemon listening on %s\n", socketPath)
+	pool := NewPool(opts.Workers, fetchArticle)
+	fmt.Printf("Daemon listening on %s (%d fetch workers)\n", socketPath, pool.Size())
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -255,8 +329,34 @@ func Serve() error {
 		}
 	})
 
-	var fetchMu sync.Mutex
-	mux.HandleFunc("/fetch", func(w http.ResponseWriter, r *http.Request) {
+	prefetcher := NewPrefetcher(pool, isCached, cacheArticle)
+	prefetcher.debug = opts.Debug
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prefetcher.Run(ctx)
+	go maintainCache(ctx)
+	if opts.FeedInterval > 0 {
+		fmt.Printf("Refreshing feeds every %s\n", opts.FeedInterval)
+		go refreshFeeds(ctx, opts.FeedInterval)
+	}
+
+	mux.HandleFunc("/fetch", fetchHandler(pool, prefetcher))
+	mux.HandleFunc("/prefetch", prefetchHandler(prefetcher))
+
+	server = &http.Server{
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 5 * time.Minute,
+	}
+
+	return server.Serve(listener)
+}
+
+// fetchHandler serves /fetch from pool, pausing prefetcher meanwhile. Each
+// request's context ends when its client disconnects, which releases its
+// share of the fetch.
+func fetchHandler(pool *Pool, prefetcher *Prefetcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
@@ -266,20 +366,17 @@ func Serve() error {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		fetchMu.Lock()
-		defer fetchMu.Unlock()
+
+		done := prefetcher.Foreground()
+		defer done()
 
 		start := time.Now()
 		logging.Debugf(req.Debug, "daemon: fetch start url=%s", req.URL)
-		cfg, cfgErr := config.Load()
-		if cfgErr != nil {
-			resp := FetchResponse{Error: cfgErr.Error()}
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(resp)
+		art, err := pool.Fetch(r.Context(), req.URL, req.Debug)
+		logging.Debugf(req.Debug, "daemon: fetch done in %s err=%v", time.Since(start), err)
+		if r.Context().Err() != nil {
 			return
 		}
-		art, err := article.FetchWithCookies(req.URL, article.FetchOptions{Debug: req.Debug}, cfg.Cookies)
-		logging.Debugf(req.Debug, "daemon: fetch done in %s err=%v", time.Since(start), err)
 
 		resp := FetchResponse{}
 		if err != nil {
@@ -295,7 +392,12 @@ func Serve() error {
 				Title:         art.Title,
 				Subtitle:      art.Subtitle,
 				DateLine:      art.DateLine,
+				Authors:       art.Authors,
+				Published:     art.Published,
+				Section:       art.Section,
+				WordCount:     art.WordCount,
 				Content:       art.Content,
+				Blocks:        art.Blocks,
 				URL:           art.URL,
 				DebugHTMLPath: art.DebugHTMLPath,
 			}
@@ -303,15 +405,88 @@ func Serve() error {
 
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(resp)
-	})
+	}
+}
 
-	server = &http.Server{
-		Handler:      mux,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 5 * time.Minute,
+func prefetchHandler(prefetcher *Prefetcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var req PrefetchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := PrefetchResponse{Queued: prefetcher.Enqueue(req.URLs)}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
 	}
+}
 
-	return server.Serve(listener)
+func fetchArticle(ctx context.Context, url string, debug bool) (*article.Article, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return article.FetchWithContext(ctx, url, article.FetchOptions{Debug: debug}, cfg.Cookies)
+}
+
+// maintainCache runs cache maintenance at startup and then every
+// maintenanceInterval until ctx is done.
+func maintainCache(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+	for {
+		result, err := cache.Maintain()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cache maintenance: %v\n", err)
+		} else if result.Expired > 0 || result.Evicted > 0 {
+			fmt.Printf("cache maintenance: %d expired, %d evicted, %d bytes kept\n", result.Expired, result.Evicted, result.Bytes)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshFeeds refreshes every section feed at startup and then every
+// interval until ctx is done. Feeds that have not changed cost a 304.
+func refreshFeeds(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// A network failure switches the process to offline mode; the
+		// daemon outlives it, so each refresh tries the network again.
+		offline.Disable()
+		if err := rss.PrefetchAll(); err != nil {
+			fmt.Fprintf(os.Stderr, "feed refresh: %v\n", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func isCached(url string) bool {
+	if _, ok, err := library.Load(url); err == nil && ok {
+		return true
+	}
+	// Lookup rather than LoadArticle, which would count a prefetch check as
+	// a read.
+	entry, ok, err := cache.Lookup(url)
+	return err == nil && ok && !entry.Expired()
+}
+
+func cacheArticle(art *article.Article) error {
+	cached := *art
+	cached.DebugHTMLPath = ""
+	return cache.SaveArticle(&cached)
 }
 
 func ping(ctx context.Context) (time.Duration, error) {
@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// DefaultPoolSize is the number of browser tabs used when none is configured.
+const DefaultPoolSize = 3
+
+// FetchFunc fetches one article. Its context is cancelled once no caller is
+// waiting for the result.
+type FetchFunc func(ctx context.Context, url string, debug bool) (*article.Article, error)
+
+// Pool runs fetches on at most size browser tabs at once. Concurrent requests
+// for the same URL share a single fetch.
+type Pool struct {
+	fetch FetchFunc
+	slots chan struct{}
+
+	mu       sync.Mutex
+	inflight map[string]*poolCall
+}
+
+type poolCall struct {
+	done    chan struct{}
+	art     *article.Article
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewPool returns a pool of size tabs, or DefaultPoolSize when size is not
+// positive.
+func NewPool(size int, fetch FetchFunc) *Pool {
+	if size <= 0 {
+		size = DefaultPoolSize
+	}
+	return &Pool{
+		fetch:    fetch,
+		slots:    make(chan struct{}, size),
+		inflight: make(map[string]*poolCall),
+	}
+}
+
+// Size returns the maximum number of concurrent fetches.
+func (p *Pool) Size() int {
+	return cap(p.slots)
+}
+
+// Fetch returns the article at url, joining an in-flight fetch of the same
+// URL if there is one. When ctx is done Fetch returns straight away, and the
+// fetch itself is cancelled if no other caller is waiting for it.
+func (p *Pool) Fetch(ctx context.Context, url string, debug bool) (*article.Article, error) {
+	key := url
+	if debug {
+		key = "debug:" + url
+	}
+
+	p.mu.Lock()
+	call, ok := p.inflight[key]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.Background())
+		call = &poolCall{done: make(chan struct{}), cancel: cancel}
+		p.inflight[key] = call
+		go p.run(fetchCtx, key, call, url, debug)
+	}
+	call.waiters++
+	p.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.art, call.err
+	case <-ctx.Done():
+		p.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			p.forget(key, call)
+		}
+		p.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pool) run(ctx context.Context, key string, call *poolCall, url string, debug bool) {
+	defer call.cancel()
+
+	select {
+	case p.slots <- struct{}{}:
+		call.art, call.err = p.fetch(ctx, url, debug)
+		<-p.slots
+	case <-ctx.Done():
+		call.err = ctx.Err()
+	}
+
+	p.mu.Lock()
+	p.forget(key, call)
+	p.mu.Unlock()
+	close(call.done)
+}
+
+// forget drops call from the in-flight map unless a newer call replaced it.
+// p.mu must be held.
+func (p *Pool) forget(key string, call *poolCall) {
+	if p.inflight[key] == call {
+		delete(p.inflight, key)
+	}
+}
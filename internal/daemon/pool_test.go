@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	var running, peak int32
+	release := make(chan struct{})
+	pool := NewPool(2, func(ctx context.Context, url string, debug bool) (*article.Article, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		return &article.Article{URL: url}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://example.com/%d", i)
+			if art, err := pool.Fetch(context.Background(), url, false); err != nil || art.URL != url {
+				t.Errorf("fetch %s: %v %#v", url, err, art)
+			}
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if peak != 2 {
+		t.Fatalf("expected at most 2 concurrent fetches, peak was %d", peak)
+	}
+}
+
+func TestPoolCoalescesSameURL(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	pool := NewPool(4, func(ctx context.Context, url string, debug bool) (*article.Article, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &article.Article{URL: url}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pool.Fetch(context.Background(), "https://example.com/same", false); err != nil {
+				t.Errorf("fetch: %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("expected one fetch for duplicate requests, got %d", calls)
+	}
+}
+
+func TestPoolCancelsWhenAllCallersLeave(t *testing.T) {
+	cancelled := make(chan struct{})
+	pool := NewPool(1, func(ctx context.Context, url string, debug bool) (*article.Article, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	stay, stayCancel := context.WithCancel(context.Background())
+	leave, leaveCancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := pool.Fetch(stay, "https://example.com/slow", false)
+		errs <- err
+	}()
+	go func() {
+		_, err := pool.Fetch(leave, "https://example.com/slow", false)
+		errs <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	leaveCancel()
+	if err := <-errs; err != context.Canceled {
+		t.Fatalf("expected the leaving caller to see context.Canceled, got %v", err)
+	}
+	select {
+	case <-cancelled:
+		t.Fatalf("fetch cancelled while a caller was still waiting")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	stayCancel()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("expected fetch to be cancelled once every caller left")
+	}
+	<-errs
+}
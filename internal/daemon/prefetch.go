@@ -0,0 +1,191 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/logging"
+)
+
+// maxPrefetchQueue caps queued URLs; the oldest requests are dropped first.
+const maxPrefetchQueue = 50
+
+// Prefetcher fetches queued URLs in the background, one at a time, and only
+// while no foreground fetch is running. A foreground fetch cancels the
+// prefetch in flight, which goes back to the front of the queue.
+type Prefetcher struct {
+	pool   *Pool
+	cached func(url string) bool
+	store  func(art *article.Article) error
+	debug  bool
+
+	mu      sync.Mutex
+	queue   []string
+	wake    chan struct{}
+	active  int
+	idle    chan struct{}
+	current string
+	cancel  context.CancelFunc
+}
+
+// NewPrefetcher returns a prefetcher that fetches through pool, skips URLs
+// for which cached reports true, and hands fetched articles to store.
+func NewPrefetcher(pool *Pool, cached func(url string) bool, store func(art *article.Article) error) *Prefetcher {
+	idle := make(chan struct{})
+	close(idle)
+	return &Prefetcher{
+		pool:   pool,
+		cached: cached,
+		store:  store,
+		wake:   make(chan struct{}, 1),
+		idle:   idle,
+	}
+}
+
+// Enqueue puts urls at the front of the queue, ahead of older requests, and
+// returns the queue length.
+func (p *Prefetcher) Enqueue(urls []string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.push(urls)
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return len(p.queue)
+}
+
+// push puts urls at the front of the queue. The caller holds p.mu.
+func (p *Prefetcher) push(urls []string) {
+	seen := make(map[string]bool, len(urls))
+	queue := make([]string, 0, len(urls)+len(p.queue))
+	for _, batch := range [][]string{urls, p.queue} {
+		for _, url := range batch {
+			if url == "" || seen[url] {
+				continue
+			}
+			seen[url] = true
+			queue = append(queue, url)
+		}
+	}
+	if len(queue) > maxPrefetchQueue {
+		queue = queue[:maxPrefetchQueue]
+	}
+	p.queue = queue
+}
+
+// Foreground marks a foreground fetch as running until the returned func is
+// called. Prefetching waits for every foreground fetch to finish.
+func (p *Prefetcher) Foreground() func() {
+	p.mu.Lock()
+	if p.active == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.active++
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+		p.push([]string{p.current})
+	}
+	p.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			p.active--
+			if p.active == 0 {
+				close(p.idle)
+			}
+			p.mu.Unlock()
+		})
+	}
+}
+
+// Run works through the queue until ctx is done.
+func (p *Prefetcher) Run(ctx context.Context) {
+	for {
+		url, ok := p.next()
+		if !ok {
+			select {
+			case <-p.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-p.idleChan():
+		case <-ctx.Done():
+			return
+		}
+		if p.cached(url) {
+			continue
+		}
+
+		fetchCtx, ok := p.start(ctx, url)
+		if !ok {
+			continue
+		}
+		art, err := p.pool.Fetch(fetchCtx, url, false)
+		if preempted := p.finish(); preempted && err != nil {
+			// A foreground fetch cancelled this one and re-queued url.
+			continue
+		}
+		if err == nil && art.Content != "" {
+			err = p.store(art)
+		}
+		if err != nil {
+			logging.Debugf(p.debug, "prefetch %s: %v", url, err)
+		}
+	}
+}
+
+// start records url as the prefetch in flight and returns its context, which
+// Foreground cancels. It fails if a foreground fetch began meanwhile, after
+// putting url back in the queue.
+func (p *Prefetcher) start(ctx context.Context, url string) (context.Context, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active > 0 {
+		p.push([]string{url})
+		return nil, false
+	}
+	fetchCtx, cancel := context.WithCancel(ctx)
+	p.current, p.cancel = url, cancel
+	return fetchCtx, true
+}
+
+// finish clears the prefetch in flight and reports whether Foreground
+// cancelled it.
+func (p *Prefetcher) finish() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	preempted := p.cancel == nil
+	if !preempted {
+		p.cancel()
+		p.cancel = nil
+	}
+	p.current = ""
+	return preempted
+}
+
+func (p *Prefetcher) next() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.queue) == 0 {
+		return "", false
+	}
+	url := p.queue[0]
+	p.queue = p.queue[1:]
+	return url, true
+}
+
+func (p *Prefetcher) idleChan() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.idle
+}
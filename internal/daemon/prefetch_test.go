@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestPrefetcherEnqueuesNewestFirst(t *testing.T) {
+	p := NewPrefetcher(nil, nil, nil)
+	p.Enqueue([]string{"a", "b"})
+	if n := p.Enqueue([]string{"c", "a", ""}); n != 3 {
+		t.Fatalf("expected 3 queued URLs, got %d", n)
+	}
+	for _, want := range []string{"c", "a", "b"} {
+		if got, _ := p.next(); got != want {
+			t.Fatalf("expected %s next, got %s", want, got)
+		}
+	}
+}
+
+func TestPrefetcherWaitsForForeground(t *testing.T) {
+	var mu sync.Mutex
+	var stored []string
+	fetched := make(chan string, 4)
+	pool := NewPool(2, func(ctx context.Context, url string, debug bool) (*article.Article, error) {
+		fetched <- url
+		return &article.Article{URL: url, Content: "Body"}, nil
+	})
+	p := NewPrefetcher(pool,
+		func(url string) bool { return url == "cached" },
+		func(art *article.Article) error {
+			mu.Lock()
+			stored = append(stored, art.URL)
+			mu.Unlock()
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Run(ctx)
+
+	done := p.Foreground()
+	p.Enqueue([]string{"cached", "fresh"})
+	select {
+	case url := <-fetched:
+		t.Fatalf("prefetched %s during a foreground fetch", url)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	done()
+	select {
+	case url := <-fetched:
+		if url != "fresh" {
+			t.Fatalf("expected only the uncached URL fetched, got %s", url)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected prefetch to resume after the foreground fetch")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(stored)
+		mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the prefetched article to be stored")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestForegroundCancelsAndRequeuesPrefetch(t *testing.T) {
+	fetched := make(chan string, 4)
+	cancelled := make(chan string, 4)
+	var mu sync.Mutex
+	calls := 0
+	pool := NewPool(2, func(ctx context.Context, url string, debug bool) (*article.Article, error) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		fetched <- url
+		if first {
+			<-ctx.Done()
+			cancelled <- url
+			return nil, ctx.Err()
+		}
+		return &article.Article{URL: url, Content: "Body"}, nil
+	})
+	stored := make(chan string, 4)
+	p := NewPrefetcher(pool,
+		func(url string) bool { return false },
+		func(art *article.Article) error {
+			stored <- art.URL
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Run(ctx)
+
+	p.Enqueue([]string{"slow"})
+	waitFor(t, fetched, "slow", "expected the prefetch to start")
+
+	done := p.Foreground()
+	waitFor(t, cancelled, "slow", "expected the foreground fetch to cancel the prefetch")
+	select {
+	case url := <-fetched:
+		t.Fatalf("prefetched %s during a foreground fetch", url)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	done()
+	waitFor(t, fetched, "slow", "expected the cancelled prefetch to be retried")
+	waitFor(t, stored, "slow", "expected the retried prefetch to be stored")
+}
+
+func waitFor(t *testing.T, ch <-chan string, want, msg string) {
+	t.Helper()
+	select {
+	case got := <-ch:
+		if got != want {
+			t.Fatalf("%s: got %s, want %s", msg, got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("%s", msg)
+	}
+}
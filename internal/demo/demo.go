@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/index"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 )
 
@@ -44,6 +47,8 @@ var demoBaseDate = time.Date(2026, time.January, 22, 9, 0, 0, 0, time.UTC)
 type Source struct {
 	sections map[string]sectionData
 	articles map[string]*article.Article
+	read     *readstate.State
+	starred  []bookmarks.Bookmark
 	loadErr  error
 }
 
@@ -98,6 +103,89 @@ func (s *Source) Article(url string) (*article.Article, error) {
 	return nil, fmt.Errorf("demo article not found")
 }
 
+// AllSections returns every demo item tagged with its section label.
+func (s *Source) AllSections() ([]rss.TaggedItem, error) {
+	if s.loadErr != nil {
+		return nil, s.loadErr
+	}
+	keys := make([]string, 0, len(s.sections))
+	for key := range s.sections {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	feeds := make([]rss.SectionItems, 0, len(keys))
+	for _, key := range keys {
+		feeds = append(feeds, rss.SectionItems{Section: demoSectionLabel(key), Items: s.sections[key].items})
+	}
+	return rss.Merge(feeds), nil
+}
+
+// Edition groups the demo items dated in the week of issue, or in the newest
+// issue when issue is zero, in print order.
+func (s *Source) Edition(issue time.Time) (rss.Edition, error) {
+	if s.loadErr != nil {
+		return rss.Edition{}, s.loadErr
+	}
+	feeds := make([]rss.SectionItems, 0, len(s.sections))
+	for key, data := range s.sections {
+		feeds = append(feeds, rss.SectionItems{Section: key, Items: data.items})
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		return feeds[i].Section < feeds[j].Section
+	})
+	return rss.BuildEdition(feeds, issue), nil
+}
+
+// ReadState returns the demo's read state. It lives in memory only so the
+// demo never touches the user's real history.
+func (s *Source) ReadState() (*readstate.State, error) {
+	if s.read == nil {
+		s.read = readstate.New()
+	}
+	return s.read, nil
+}
+
+func (s *Source) MarkRead(keys ...string) error {
+	state, _ := s.ReadState()
+	state.Mark(keys...)
+	return nil
+}
+
+func (s *Source) SavePosition(url string, pos readstate.Position) error {
+	state, _ := s.ReadState()
+	state.SetPosition(url, pos)
+	return nil
+}
+
+// Bookmarks returns bookmarks starred during this demo session.
+func (s *Source) Bookmarks() ([]bookmarks.Bookmark, error) {
+	return s.starred, nil
+}
+
+func (s *Source) ToggleBookmark(item rss.Item, sections []string) (bool, error) {
+	for i, b := range s.starred {
+		if b.URL == item.Link {
+			s.starred = append(s.starred[:i:i], s.starred[i+1:]...)
+			return false, nil
+		}
+	}
+	s.starred = append([]bookmarks.Bookmark{bookmarks.New(item, sections)}, s.starred...)
+	return true, nil
+}
+
+// BodyIndex returns a full-text index of the demo articles.
+func (s *Source) BodyIndex() (*index.Index, error) {
+	if s.loadErr != nil {
+		return nil, s.loadErr
+	}
+	articles := make([]*article.Article, 0, len(s.articles))
+	for _, art := range s.articles {
+		articles = append(articles, art)
+	}
+	return index.New(articles), nil
+}
+
 func (s *Source) addFixtures() error {
 	specs, err := loadFixtureSpecs()
 	if err != nil {
@@ -159,7 +247,7 @@ func (s *Source) addFixtures() error {
 			Overtitle: fmt.Sprintf("%s | Demo", demoSectionLabel(sectionKey)),
 			Title:     spec.Title,
 			Subtitle:  spec.Subtitle,
-			DateLine:  formatDateLine(published),
+			DateLine:  article.FormatDateLine(published),
 			Content:   content,
 			URL:       url,
 		}
@@ -203,22 +291,6 @@ func buildContent(title string) string {
 	return strings.Join(paragraphs, "\n\n")
 }
 
-func formatDateLine(t time.Time) string {
-	day := t.Day()
-	suffix := "th"
-	if day%100 < 11 || day%100 > 13 {
-		switch day % 10 {
-		case 1:
-			suffix = "st"
-		case 2:
-			suffix = "nd"
-		case 3:
-			suffix = "rd"
-		}
-	}
-	return fmt.Sprintf("%s %d%s %d", t.Format("Jan"), day, suffix, t.Year())
-}
-
 func demoSectionLabel(section string) string {
 	if title, ok := demoSectionTitles[section]; ok {
 		return title
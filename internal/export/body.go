@@ -0,0 +1,120 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// bodyHTML renders article blocks as HTML elements, one per line with the
+// given indent. The output is also well-formed XHTML. imageSrc gives the src
+// to use for a figure's image, or "" to link to the image instead.
+func bodyHTML(blocks []article.Block, indent string, imageSrc func(url string) string) string {
+	var b strings.Builder
+	for _, block := range blocks {
+		switch block.Kind {
+		case article.BlockHeading:
+			level := block.Level
+			if level < 2 || level > 4 {
+				level = 2
+			}
+			fmt.Fprintf(&b, "%s<h%d>%s</h%d>\n", indent, level, spansHTML(block.Spans), level)
+		case article.BlockQuote:
+			fmt.Fprintf(&b, "%s<blockquote><p>%s</p></blockquote>\n", indent, spansHTML(block.Spans))
+		case article.BlockPullQuote:
+			fmt.Fprintf(&b, "%s<aside class=\"pullquote\"><p>%s</p></aside>\n", indent, spansHTML(block.Spans))
+		case article.BlockList:
+			tag := "ul"
+			if block.Ordered {
+				tag = "ol"
+			}
+			fmt.Fprintf(&b, "%s<%s>\n", indent, tag)
+			for _, item := range block.Items {
+				fmt.Fprintf(&b, "%s  <li>%s</li>\n", indent, spansHTML(item))
+			}
+			fmt.Fprintf(&b, "%s</%s>\n", indent, tag)
+		case article.BlockFigure:
+			fmt.Fprintf(&b, "%s%s\n", indent, figureHTML(block, imageSrc))
+		case article.BlockTable:
+			writeTableHTML(&b, block, indent)
+		default:
+			fmt.Fprintf(&b, "%s<p>%s</p>\n", indent, spansHTML(block.Spans))
+		}
+	}
+	return b.String()
+}
+
+// figureHTML renders a figure with its caption and credit, and its image or,
+// when there is none to show, its placeholder text.
+func figureHTML(block article.Block, imageSrc func(url string) string) string {
+	figure := block.Figure()
+	src := ""
+	if figure.Src != "" {
+		src = imageSrc(figure.Src)
+	}
+
+	var b strings.Builder
+	b.WriteString("<figure>")
+	switch {
+	case src != "":
+		alt := figure.Alt
+		if alt == "" {
+			alt = figure.Caption
+		}
+		fmt.Fprintf(&b, "<img src=\"%s\" alt=\"%s\"/>", esc(src), esc(alt))
+	case figure.Src != "":
+		fmt.Fprintf(&b, "<p class=\"placeholder\"><a href=\"%s\">%s</a></p>", esc(figure.Src), esc(figure.Placeholder()))
+	case figure.Alt != "":
+		fmt.Fprintf(&b, "<p class=\"placeholder\">%s</p>", esc(figure.Placeholder()))
+	}
+	if len(block.Spans) > 0 || figure.Credit != "" {
+		b.WriteString("<figcaption>" + spansHTML(block.Spans))
+		if figure.Credit != "" {
+			if len(block.Spans) > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "<span class=\"credit\">%s</span>", esc(figure.Credit))
+		}
+		b.WriteString("</figcaption>")
+	}
+	b.WriteString("</figure>")
+	return b.String()
+}
+
+func writeTableHTML(b *strings.Builder, block article.Block, indent string) {
+	fmt.Fprintf(b, "%s<table>\n", indent)
+	for i, row := range block.Rows {
+		cell := "td"
+		if i == 0 && block.Header {
+			cell = "th"
+		}
+		fmt.Fprintf(b, "%s  <tr>", indent)
+		for _, text := range row {
+			fmt.Fprintf(b, "<%s>%s</%s>", cell, esc(text), cell)
+		}
+		b.WriteString("</tr>\n")
+	}
+	fmt.Fprintf(b, "%s</table>\n", indent)
+}
+
+func spansHTML(spans []article.Span) string {
+	var b strings.Builder
+	for i, span := range spans {
+		if span.Link != "" && (i == 0 || spans[i-1].Link != span.Link) {
+			fmt.Fprintf(&b, "<a href=\"%s\">", esc(span.Link))
+		}
+		text := esc(span.Text)
+		if span.Italic {
+			text = "<em>" + text + "</em>"
+		}
+		if span.Bold {
+			text = "<strong>" + text + "</strong>"
+		}
+		b.WriteString(text)
+		if span.Link != "" && (i == len(spans)-1 || spans[i+1].Link != span.Link) {
+			b.WriteString("</a>")
+		}
+	}
+	return b.String()
+}
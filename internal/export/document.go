@@ -0,0 +1,165 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+// Meta is front-matter metadata that an article does not carry itself.
+type Meta struct {
+	Section   string
+	FetchedAt time.Time
+}
+
+// WriteMarkdown writes art as Markdown with YAML front matter.
+func WriteMarkdown(w io.Writer, art *article.Article, meta Meta) error {
+	_, err := io.WriteString(w, frontMatter(art, meta, strconv.Quote)+"\n"+art.ToMarkdown())
+	return err
+}
+
+// WriteHTML writes art as a standalone HTML page with an embedded stylesheet.
+// The front matter is kept as a comment so static-site tools can still read it;
+// its values never contain "--", so they cannot end the comment early.
+func WriteHTML(w io.Writer, art *article.Article, meta Meta) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<!--\n" + frontMatter(art, meta, commentQuote) + "-->\n")
+	b.WriteString("<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(art.Title))
+	if art.URL != "" {
+		fmt.Fprintf(&b, "<link rel=\"canonical\" href=\"%s\">\n", html.EscapeString(art.URL))
+	}
+	b.WriteString("<style>\n" + htmlStylesheet() + "</style>\n</head>\n<body>\n<article>\n")
+	if art.Overtitle != "" {
+		fmt.Fprintf(&b, "<p class=\"overtitle\">%s</p>\n", html.EscapeString(art.Overtitle))
+	}
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(art.Title))
+	if art.Subtitle != "" {
+		fmt.Fprintf(&b, "<p class=\"subtitle\">%s</p>\n", html.EscapeString(art.Subtitle))
+	}
+	if art.DateLine != "" {
+		fmt.Fprintf(&b, "<p class=\"dateline\">%s</p>\n", html.EscapeString(art.DateLine))
+	}
+	b.WriteString("<hr>\n")
+	b.WriteString(bodyHTML(art.BodyBlocks(), "", func(url string) string { return url }))
+	if art.URL != "" {
+		escaped := html.EscapeString(art.URL)
+		fmt.Fprintf(&b, "<footer><a href=\"%s\">%s</a></footer>\n", escaped, escaped)
+	}
+	b.WriteString("</article>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// frontMatter renders YAML front matter, quoting strings with quote.
+func frontMatter(art *article.Article, meta Meta, quote func(string) string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	field := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", key, quote(value))
+		}
+	}
+	field("title", art.Title)
+	field("subtitle", art.Subtitle)
+	field("overtitle", art.Overtitle)
+	field("date", art.DateLine)
+	if !art.Published.IsZero() {
+		fmt.Fprintf(&b, "published: %s\n", art.Published.UTC().Format(time.RFC3339))
+	}
+	if len(art.Authors) > 0 {
+		quoted := make([]string, len(art.Authors))
+		for i, author := range art.Authors {
+			quoted[i] = quote(author)
+		}
+		fmt.Fprintf(&b, "authors: [%s]\n", strings.Join(quoted, ", "))
+	}
+	field("url", art.URL)
+	field("section", meta.Section)
+	if art.WordCount > 0 {
+		fmt.Fprintf(&b, "word_count: %d\n", art.WordCount)
+	}
+	if !meta.FetchedAt.IsZero() {
+		fmt.Fprintf(&b, "fetched_at: %s\n", meta.FetchedAt.UTC().Format(time.RFC3339))
+	}
+	b.WriteString("---\n")
+	return b.String()
+}
+
+// commentQuote quotes s as a YAML string that can sit inside an HTML comment,
+// writing each "--" as "-\u002d".
+func commentQuote(s string) string {
+	return strings.ReplaceAll(strconv.Quote(s), "--", `-\u002d`)
+}
+
+// htmlStylesheet uses the light theme palette, switching to the dark one
+// when the reader prefers it.
+func htmlStylesheet() string {
+	light, dark := ui.DefaultTheme, ui.DarkTheme
+	return fmt.Sprintf(`:root { --brand: %s; --text: %s; --muted: %s; --faint: %s; --border: %s; --background: %s; }
+@media (prefers-color-scheme: dark) {
+  :root { --text: %s; --muted: %s; --faint: %s; --border: %s; --background: %s; }
+}
+body { background: var(--background); color: var(--text); font: 19px/1.6 Georgia, "Times New Roman", serif; margin: 0; }
+article { max-width: 40em; margin: 0 auto; padding: 3em 1.5em; }
+.overtitle { color: var(--brand); font: bold 0.8em/1.2 "Helvetica Neue", Arial, sans-serif; letter-spacing: 0.05em; margin: 0; text-transform: uppercase; }
+h1 { font-size: 2em; line-height: 1.15; margin: 0.3em 0; }
+.subtitle { color: var(--muted); font-size: 1.2em; font-style: italic; margin: 0 0 0.5em; }
+.dateline { color: var(--muted); font: 0.8em/1.4 "Helvetica Neue", Arial, sans-serif; margin: 0; }
+hr { border: 0; border-top: 4px solid var(--brand); margin: 1.5em 0; width: 3em; }
+h2, h3, h4 { font-size: 1.1em; margin: 1.5em 0 0.5em; }
+blockquote { border-left: 3px solid var(--border); color: var(--muted); margin: 1em 0; padding-left: 1em; }
+.pullquote { color: var(--brand); font-size: 1.3em; font-style: italic; margin: 1.5em 0; }
+figure { margin: 1.5em 0; }
+figure img { max-width: 100%%; }
+figcaption { color: var(--muted); font: 0.8em/1.4 "Helvetica Neue", Arial, sans-serif; }
+.credit { font-style: italic; }
+.placeholder { font-style: italic; }
+table { border-collapse: collapse; font: 0.85em/1.4 "Helvetica Neue", Arial, sans-serif; margin: 1em 0; }
+th, td { border-bottom: 1px solid var(--border); padding: 0.3em 0.6em; text-align: left; }
+footer { border-top: 1px solid var(--border); color: var(--faint); font: 0.75em/1.4 "Helvetica Neue", Arial, sans-serif; margin-top: 2em; padding-top: 1em; }
+footer a { color: inherit; }
+`,
+		light.Brand, light.Text, light.TextMuted, light.TextFaint, light.Border, light.Background,
+		dark.Text, dark.TextMuted, dark.TextFaint, dark.Border, dark.Background)
+}
+
+// Slug returns a file name stem for art: the last path segment of its URL,
+// or its title.
+func Slug(art *article.Article) string {
+	if u, err := url.Parse(art.URL); err == nil {
+		if slug := slugify(path.Base(u.Path)); slug != "" {
+			return slug
+		}
+	}
+	return Slugify(art.Title)
+}
+
+// Slugify lowercases text and joins its words with hyphens.
+func Slugify(text string) string {
+	if slug := slugify(text); slug != "" {
+		return slug
+	}
+	return "economist"
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(text string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(slug) > 80 {
+		slug = strings.TrimRight(slug[:80], "-")
+	}
+	return slug
+}
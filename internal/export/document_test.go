@@ -0,0 +1,127 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+var testArticle = &article.Article{
+	Overtitle: "Monetary policy",
+	Title:     `The "last mile" of inflation`,
+	Subtitle:  "Central banks <still> have work to do",
+	DateLine:  "Jan 22nd 2026",
+	Authors:   []string{"The Economist"},
+	Published: time.Date(2026, 1, 22, 6, 0, 0, 0, time.UTC),
+	WordCount: 5,
+	Content:   "First paragraph.\n\nSecond & final paragraph.",
+	URL:       "https://www.economist.com/finance-and-economics/2026/01/22/the-last-mile-of-inflation",
+}
+
+func TestWriteMarkdownFrontMatter(t *testing.T) {
+	var buf bytes.Buffer
+	meta := Meta{Section: "finance-and-economics", FetchedAt: time.Date(2026, 1, 22, 9, 30, 0, 0, time.UTC)}
+	if err := WriteMarkdown(&buf, testArticle, meta); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "---\n") {
+		t.Fatalf("expected front matter first, got %q", out[:20])
+	}
+	for _, want := range []string{
+		`title: "The \"last mile\" of inflation"`,
+		`overtitle: "Monetary policy"`,
+		`date: "Jan 22nd 2026"`,
+		"published: 2026-01-22T06:00:00Z",
+		`authors: ["The Economist"]`,
+		`section: "finance-and-economics"`,
+		"word_count: 5",
+		"fetched_at: 2026-01-22T09:30:00Z",
+		"# The \"last mile\" of inflation",
+		"Second & final paragraph.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteHTMLEscapesAndStyles(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, testArticle, Meta{}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"&lt;still&gt;",
+		"<p>Second &amp; final paragraph.</p>",
+		"--brand: #E3120B",
+		"prefers-color-scheme: dark",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteHTMLFrontMatterCannotCloseComment(t *testing.T) {
+	art := &article.Article{Title: "Ends here --> <script>", URL: "https://example.com/a--b"}
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, art, Meta{}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out := buf.String()
+	comment := out[strings.Index(out, "<!--")+len("<!--") : strings.Index(out, "<html")]
+	if strings.Count(comment, "--") != 3 || !strings.HasSuffix(comment, "---\n-->\n") {
+		t.Fatalf("expected only the front matter fences and comment end to use --, got:\n%s", comment)
+	}
+	if !strings.Contains(comment, `title: "Ends here -\u002d> <script>"`) {
+		t.Fatalf("expected escaped title in:\n%s", comment)
+	}
+}
+
+func TestWriteHTMLRendersBlocks(t *testing.T) {
+	art := &article.Article{
+		Title: "Structured",
+		Blocks: []article.Block{
+			{Kind: article.BlockHeading, Level: 2, Spans: []article.Span{{Text: "Subhead"}}},
+			{Kind: article.BlockParagraph, Spans: []article.Span{{Text: "Some "}, {Text: "bold", Bold: true}, {Text: " & "}, {Text: "italic", Italic: true, Link: "https://example.com/x?a=1&b=2"}}},
+			{Kind: article.BlockList, Ordered: true, Items: [][]article.Span{{{Text: "One"}}}},
+			{Kind: article.BlockFigure, Src: "https://example.com/chart.png", Spans: []article.Span{{Text: "A chart"}}},
+			{Kind: article.BlockFigure, Src: "https://example.com/photo.jpg", Alt: "A port", Credit: "Photograph: Getty Images"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, art, Meta{}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<h2>Subhead</h2>",
+		`<p>Some <strong>bold</strong> &amp; <a href="https://example.com/x?a=1&amp;b=2"><em>italic</em></a></p>`,
+		"<ol>\n  <li>One</li>\n</ol>",
+		`<figure><img src="https://example.com/chart.png" alt="A chart"/><figcaption>A chart</figcaption></figure>`,
+		`<figure><img src="https://example.com/photo.jpg" alt="A port"/><figcaption><span class="credit">Photograph: Getty Images</span></figcaption></figure>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestSlug(t *testing.T) {
+	if got := Slug(testArticle); got != "the-last-mile-of-inflation" {
+		t.Fatalf("expected URL slug, got %q", got)
+	}
+	if got := Slug(&article.Article{Title: "Hello, World!"}); got != "hello-world" {
+		t.Fatalf("expected title slug, got %q", got)
+	}
+}
@@ -0,0 +1,285 @@
+package export
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+const (
+	epubMimetype  = "application/epub+zip"
+	epubPublisher = "The Economist"
+	epubLanguage  = "en-GB"
+)
+
+// Book is a collection of articles exported as a single document.
+type Book struct {
+	Title    string
+	Subtitle string
+	Articles []*article.Article
+	Created  time.Time
+	// Images holds downloaded figure images by URL, from
+	// article.FetchImages. Figures without one link to the image instead.
+	Images map[string]article.Image
+}
+
+// WriteEPUB writes book as an EPUB 3 package with one chapter per article.
+// An NCX table of contents is included for older e-readers.
+func WriteEPUB(w io.Writer, book Book) error {
+	if len(book.Articles) == 0 {
+		return fmt.Errorf("no articles to export")
+	}
+	if book.Created.IsZero() {
+		book.Created = time.Now()
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed.
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, epubMimetype); err != nil {
+		return err
+	}
+
+	id := bookID(book)
+	images := epubImages(book)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", epubContainer},
+		{"OEBPS/content.opf", epubPackage(book, id, images)},
+		{"OEBPS/nav.xhtml", epubNav(book)},
+		{"OEBPS/toc.ncx", epubNCX(book, id)},
+		{"OEBPS/style.css", epubStylesheet},
+	}
+	for i, art := range book.Articles {
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + chapterFile(i), epubChapter(art, images)})
+	}
+	for _, image := range images {
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + image.file, string(image.Data)})
+	}
+
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, file.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const epubStylesheet = `body { font-family: serif; line-height: 1.5; margin: 0 5%; }
+h1 { font-size: 1.6em; line-height: 1.2; margin: 0.4em 0; }
+.overtitle { color: #e3120b; font-family: sans-serif; font-size: 0.8em; font-weight: bold; text-transform: uppercase; letter-spacing: 0.05em; margin: 1em 0 0; }
+.subtitle { font-style: italic; font-size: 1.1em; margin: 0 0 0.6em; }
+.dateline { color: #595959; font-family: sans-serif; font-size: 0.8em; margin: 0 0 1.5em; }
+p { margin: 0 0 0.8em; text-indent: 0; }
+h2, h3, h4 { font-size: 1.1em; margin: 1.2em 0 0.4em; }
+blockquote { font-style: italic; margin: 0.8em 1.5em; }
+.pullquote { color: #e3120b; font-size: 1.2em; font-style: italic; margin: 1em 0; }
+figure { margin: 1em 0; }
+figure img { max-width: 100%; }
+figcaption { color: #595959; font-family: sans-serif; font-size: 0.8em; }
+.credit, .placeholder { font-style: italic; }
+table { border-collapse: collapse; font-family: sans-serif; font-size: 0.8em; margin: 0.8em 0; }
+th, td { border-bottom: 1px solid #ccc; padding: 0.2em 0.5em; text-align: left; }
+.source { color: #595959; font-size: 0.8em; word-break: break-all; }
+`
+
+// epubImage is a figure image stored in the package.
+type epubImage struct {
+	article.Image
+	id   string
+	file string
+	url  string
+}
+
+// epubImages numbers the book's downloaded images in order of appearance.
+func epubImages(book Book) []epubImage {
+	var images []epubImage
+	seen := make(map[string]bool)
+	for _, art := range book.Articles {
+		for _, figure := range art.Figures() {
+			image, ok := book.Images[figure.Src]
+			if !ok || seen[figure.Src] {
+				continue
+			}
+			seen[figure.Src] = true
+			n := len(images) + 1
+			images = append(images, epubImage{
+				Image: image,
+				id:    fmt.Sprintf("image-%03d", n),
+				file:  fmt.Sprintf("images/figure-%03d%s", n, image.Extension()),
+				url:   figure.Src,
+			})
+		}
+	}
+	return images
+}
+
+func epubPackage(book Book, id string, images []epubImage) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="` + epubLanguage + `">` + "\n")
+	b.WriteString(`  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">` + "\n")
+	fmt.Fprintf(&b, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", id)
+	fmt.Fprintf(&b, "    <dc:title>%s</dc:title>\n", esc(book.Title))
+	if book.Subtitle != "" {
+		fmt.Fprintf(&b, "    <dc:description>%s</dc:description>\n", esc(book.Subtitle))
+	}
+	fmt.Fprintf(&b, "    <dc:language>%s</dc:language>\n", epubLanguage)
+	fmt.Fprintf(&b, "    <dc:creator>%s</dc:creator>\n", epubPublisher)
+	fmt.Fprintf(&b, "    <dc:publisher>%s</dc:publisher>\n", epubPublisher)
+	fmt.Fprintf(&b, "    <dc:date>%s</dc:date>\n", book.Created.UTC().Format("2006-01-02"))
+	fmt.Fprintf(&b, "    <meta property=\"dcterms:modified\">%s</meta>\n", book.Created.UTC().Format("2006-01-02T15:04:05Z"))
+	b.WriteString("  </metadata>\n  <manifest>\n")
+	b.WriteString(`    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	b.WriteString(`    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>` + "\n")
+	b.WriteString(`    <item id="css" href="style.css" media-type="text/css"/>` + "\n")
+	for i := range book.Articles {
+		fmt.Fprintf(&b, "    <item id=\"%s\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", chapterID(i), chapterFile(i))
+	}
+	for _, image := range images {
+		fmt.Fprintf(&b, "    <item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n", image.id, image.file, image.MediaType)
+	}
+	b.WriteString("  </manifest>\n  <spine toc=\"ncx\">\n")
+	for i := range book.Articles {
+		fmt.Fprintf(&b, "    <itemref idref=\"%s\"/>\n", chapterID(i))
+	}
+	b.WriteString("  </spine>\n</package>\n")
+	return b.String()
+}
+
+func epubNav(book Book) string {
+	var b strings.Builder
+	b.WriteString(xhtmlHead(book.Title))
+	b.WriteString("  <nav epub:type=\"toc\" id=\"toc\">\n")
+	fmt.Fprintf(&b, "    <h1>%s</h1>\n", esc(book.Title))
+	if book.Subtitle != "" {
+		fmt.Fprintf(&b, "    <p class=\"subtitle\">%s</p>\n", esc(book.Subtitle))
+	}
+	b.WriteString("    <ol>\n")
+	for i, art := range book.Articles {
+		fmt.Fprintf(&b, "      <li><a href=\"%s\">%s</a></li>\n", chapterFile(i), esc(chapterTitle(art)))
+	}
+	b.WriteString("    </ol>\n  </nav>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func epubNCX(book Book, id string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">` + "\n")
+	fmt.Fprintf(&b, "  <head>\n    <meta name=\"dtb:uid\" content=\"%s\"/>\n  </head>\n", id)
+	fmt.Fprintf(&b, "  <docTitle><text>%s</text></docTitle>\n  <navMap>\n", esc(book.Title))
+	for i, art := range book.Articles {
+		fmt.Fprintf(&b, "    <navPoint id=\"nav-%s\" playOrder=\"%d\">\n", chapterID(i), i+1)
+		fmt.Fprintf(&b, "      <navLabel><text>%s</text></navLabel>\n", esc(chapterTitle(art)))
+		fmt.Fprintf(&b, "      <content src=\"%s\"/>\n    </navPoint>\n", chapterFile(i))
+	}
+	b.WriteString("  </navMap>\n</ncx>\n")
+	return b.String()
+}
+
+func epubChapter(art *article.Article, images []epubImage) string {
+	var b strings.Builder
+	b.WriteString(xhtmlHead(chapterTitle(art)))
+	b.WriteString("  <section epub:type=\"chapter\">\n")
+	if art.Overtitle != "" {
+		fmt.Fprintf(&b, "    <p class=\"overtitle\">%s</p>\n", esc(art.Overtitle))
+	}
+	fmt.Fprintf(&b, "    <h1>%s</h1>\n", esc(chapterTitle(art)))
+	if art.Subtitle != "" {
+		fmt.Fprintf(&b, "    <p class=\"subtitle\">%s</p>\n", esc(art.Subtitle))
+	}
+	if art.DateLine != "" {
+		fmt.Fprintf(&b, "    <p class=\"dateline\">%s</p>\n", esc(art.DateLine))
+	}
+	b.WriteString(bodyHTML(art.BodyBlocks(), "    ", func(url string) string {
+		for _, image := range images {
+			if image.url == url {
+				return image.file
+			}
+		}
+		return ""
+	}))
+	if art.URL != "" {
+		fmt.Fprintf(&b, "    <p class=\"source\">%s</p>\n", esc(art.URL))
+	}
+	b.WriteString("  </section>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func xhtmlHead(title string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="` + epubLanguage + `" lang="` + epubLanguage + `">
+<head>
+  <meta charset="UTF-8"/>
+  <title>` + esc(title) + `</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+`
+}
+
+func chapterTitle(art *article.Article) string {
+	if art.Title != "" {
+		return art.Title
+	}
+	return art.URL
+}
+
+func chapterID(i int) string {
+	return fmt.Sprintf("chapter-%03d", i+1)
+}
+
+func chapterFile(i int) string {
+	return chapterID(i) + ".xhtml"
+}
+
+// bookID derives a stable UUID URN from the article URLs, so re-exporting
+// the same articles updates the book on the reader instead of duplicating it.
+func bookID(book Book) string {
+	h := sha1.New()
+	for _, art := range book.Articles {
+		io.WriteString(h, art.URL+"\n")
+	}
+	sum := h.Sum(nil)
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func esc(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestWriteEPUB(t *testing.T) {
+	book := Book{
+		Title:    "Leaders",
+		Subtitle: "The Economist",
+		Created:  time.Date(2026, 1, 22, 9, 0, 0, 0, time.UTC),
+		Articles: []*article.Article{
+			{
+				Overtitle: "Trade",
+				Title:     "Tariffs & <trouble>",
+				Subtitle:  "A subtitle",
+				DateLine:  "Jan 22nd 2026",
+				Content:   "First paragraph.\n\nSecond paragraph. ■",
+				URL:       "https://example.com/a",
+			},
+			{Title: "Second article", Content: "Body.", URL: "https://example.com/b"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, book); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	first := zr.File[0]
+	if first.Name != "mimetype" || first.Method != zip.Store {
+		t.Fatalf("expected stored mimetype first, got %s method %d", first.Name, first.Method)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+		if f.Name != "mimetype" && !strings.HasSuffix(f.Name, ".css") {
+			if err := wellFormed(data); err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", f.Name, err)
+			}
+		}
+	}
+
+	if files["mimetype"] != epubMimetype {
+		t.Fatalf("unexpected mimetype %q", files["mimetype"])
+	}
+	opf := files["OEBPS/content.opf"]
+	for _, want := range []string{"<dc:title>Leaders</dc:title>", `idref="chapter-002"`, "2026-01-22T09:00:00Z", "urn:uuid:"} {
+		if !strings.Contains(opf, want) {
+			t.Fatalf("expected package to contain %q:\n%s", want, opf)
+		}
+	}
+	if nav := files["OEBPS/nav.xhtml"]; !strings.Contains(nav, "Tariffs &amp; &lt;trouble&gt;") || !strings.Contains(nav, "chapter-002.xhtml") {
+		t.Fatalf("expected escaped table of contents, got:\n%s", nav)
+	}
+	chapter := files["OEBPS/chapter-001.xhtml"]
+	for _, want := range []string{"Trade", "A subtitle", "Jan 22nd 2026", "<p>Second paragraph. ■</p>"} {
+		if !strings.Contains(chapter, want) {
+			t.Fatalf("expected chapter to contain %q:\n%s", want, chapter)
+		}
+	}
+}
+
+func TestWriteEPUBEmbedsImages(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chart.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(png)
+	}))
+	defer server.Close()
+
+	art := &article.Article{
+		Title: "Graphic detail",
+		Blocks: []article.Block{
+			{Kind: article.BlockFigure, Src: server.URL + "/chart.png", Alt: "Bar chart", Spans: []article.Span{{Text: "Growth"}}, Credit: "Chart: The Economist"},
+			{Kind: article.BlockFigure, Src: server.URL + "/gone.png", Spans: []article.Span{{Text: "Lost"}}},
+		},
+	}
+	images, errs := article.FetchImages(server.Client(), art)
+	if len(images) != 1 || len(errs) != 1 || images[server.URL+"/chart.png"].MediaType != "image/png" {
+		t.Fatalf("expected one png and one failure, got %v %v", images, errs)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, Book{Title: "Charts", Articles: []*article.Article{art}, Images: images}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	if files["OEBPS/images/figure-001.png"] != string(png) {
+		t.Fatalf("expected embedded image, got files %v", len(files))
+	}
+	if opf := files["OEBPS/content.opf"]; !strings.Contains(opf, `href="images/figure-001.png" media-type="image/png"`) {
+		t.Fatalf("expected image in manifest:\n%s", opf)
+	}
+	chapter := files["OEBPS/chapter-001.xhtml"]
+	for _, want := range []string{
+		`<img src="images/figure-001.png" alt="Bar chart"/><figcaption>Growth <span class="credit">Chart: The Economist</span></figcaption>`,
+		`<a href="` + server.URL + `/gone.png">[Image: Lost]</a>`,
+	} {
+		if !strings.Contains(chapter, want) {
+			t.Fatalf("expected chapter to contain %q:\n%s", want, chapter)
+		}
+	}
+	if err := wellFormed([]byte(chapter)); err != nil {
+		t.Fatalf("chapter is not well-formed XML: %v", err)
+	}
+}
+
+func TestWriteEPUBRequiresArticles(t *testing.T) {
+	if err := WriteEPUB(io.Discard, Book{Title: "Empty"}); err == nil {
+		t.Fatalf("expected error for empty book")
+	}
+}
+
+func wellFormed(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
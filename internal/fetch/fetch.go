@@ -13,33 +13,49 @@ import (
 	"github.com/tmustier/economist-tui/internal/config"
 	"github.com/tmustier/economist-tui/internal/daemon"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/library"
 	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/offline"
 )
 
 type Options struct {
 	Debug bool
 }
 
-var purgeOnce sync.Once
+var maintainOnce sync.Once
 
 func FetchArticle(url string, opts Options) (*article.Article, error) {
 	logging.Debugf(opts.Debug, "read: start url=%s", url)
 
+	if offline.Enabled() {
+		return fetchOffline(url, opts)
+	}
+
 	if !opts.Debug {
-		purgeOnce.Do(func() {
-			if err := cache.PurgeExpired(); err != nil {
-				logging.Debugf(opts.Debug, "read: cache purge error: %v", err)
-			}
-		})
-		if cached, ok, err := cache.LoadArticle(url); err == nil && ok {
+		if saved, ok, err := library.Load(url); err == nil && ok {
+			logging.Debugf(opts.Debug, "read: library hit")
+			return validateArticle(saved)
+		} else if err != nil {
+			logging.Debugf(opts.Debug, "read: library load error: %v", err)
+		}
+		// Expired entries are kept until a fetch succeeds, in case the
+		// network turns out to be down.
+		if cached, ok, err := cache.Lookup(url); err == nil && ok && !cached.Expired() {
 			logging.Debugf(opts.Debug, "read: cache hit")
-			return validateArticle(cached)
+			cache.TouchArticle(url)
+			return validateArticle(&cached.Article)
 		} else if err != nil {
 			logging.Debugf(opts.Debug, "read: cache load error: %v", err)
 		}
 	}
 
 	art, err := fetchViaDaemon(url, opts)
+	// Only the daemon's own fetch errors count here; failing to reach its
+	// socket is not a network failure.
+	if offline.NoteFailure(err) {
+		logging.Debugf(opts.Debug, "read: network unreachable, switching to offline mode")
+		return fetchOffline(url, opts)
+	}
 	if err == nil {
 		logging.Debugf(opts.Debug, "read: daemon fetch ok")
 		art, err = validateArticle(art)
@@ -55,6 +71,10 @@ func FetchArticle(url string, opts Options) (*article.Article, error) {
 
 	logging.Debugf(opts.Debug, "read: daemon unavailable, using local fetch")
 	art, err = fetchLocal(url, opts)
+	if offline.NoteFailure(err) {
+		logging.Debugf(opts.Debug, "read: network unreachable, switching to offline mode")
+		return fetchOffline(url, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +86,74 @@ func FetchArticle(url string, opts Options) (*article.Article, error) {
 	return cacheArticle(art, opts)
 }
 
+// fetchOffline serves url from the library or the cache, including entries
+// past their TTL.
+func fetchOffline(url string, opts Options) (*article.Article, error) {
+	if saved, ok, err := library.Load(url); err == nil && ok {
+		logging.Debugf(opts.Debug, "read: offline library hit")
+		return validateArticle(saved)
+	}
+	if cached, ok, err := cache.Lookup(url); err == nil && ok {
+		logging.Debugf(opts.Debug, "read: offline cache hit")
+		cache.TouchArticle(url)
+		return validateArticle(&cached.Article)
+	}
+	return nil, appErrors.NewUserError("offline - this article is not cached or saved")
+}
+
+// Available reports which of urls can be read without the network. The
+// cache is queried once for all of them.
+func Available(urls []string) map[string]bool {
+	available := make(map[string]bool, len(urls))
+	var rest []string
+	for _, url := range urls {
+		if library.Has(url) {
+			available[url] = true
+		} else {
+			rest = append(rest, url)
+		}
+	}
+	if len(rest) == 0 {
+		return available
+	}
+	cached, err := cache.FindArticles(cache.Query{URLs: rest})
+	if err != nil {
+		return available
+	}
+	for _, entry := range cached {
+		if entry.Article.Content != "" {
+			available[entry.Article.URL] = true
+		}
+	}
+	return available
+}
+
+// Prefetch asks the daemon to fetch urls into the cache in the background,
+// skipping saved and cached articles. It does not start the daemon, and
+// fails while offline so callers retry the URLs later.
+func Prefetch(urls []string) error {
+	if offline.Enabled() {
+		return errors.New("offline - not prefetching")
+	}
+	var missing []string
+	for _, url := range urls {
+		if _, ok, err := library.Load(url); err == nil && ok {
+			continue
+		}
+		if cached, ok, err := cache.Lookup(url); err == nil && ok && !cached.Expired() {
+			continue
+		}
+		missing = append(missing, url)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return daemon.Prefetch(ctx, missing)
+}
+
 func fetchViaDaemon(url string, opts Options) (*article.Article, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), browser.FetchTimeout)
 	defer cancel()
@@ -126,6 +214,12 @@ func cacheArticle(art *article.Article, opts Options) (*article.Article, error)
 		return art, nil
 	}
 
+	maintainOnce.Do(func() {
+		if _, err := cache.Maintain(); err != nil {
+			logging.Debugf(opts.Debug, "read: cache maintenance error: %v", err)
+		}
+	})
+
 	cached := *art
 	cached.DebugHTMLPath = ""
 	if err := cache.SaveArticle(&cached); err != nil {
@@ -0,0 +1,523 @@
+package index
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+const (
+	indexFileName  = "search-index.json"
+	indexVersion   = 1
+	titleParagraph = -1
+	titleWeight    = 3.0
+	snippetLen     = 240
+)
+
+// Document is an indexed article body split into paragraphs.
+type Document struct {
+	URL        string    `json:"url"`
+	Title      string    `json:"title"`
+	Subtitle   string    `json:"subtitle,omitempty"`
+	DateLine   string    `json:"date_line,omitempty"`
+	Paragraphs []string  `json:"paragraphs"`
+	Saved      bool      `json:"saved,omitempty"`
+	IndexedAt  time.Time `json:"indexed_at"`
+	Length     int       `json:"length"`
+}
+
+// Posting records how often a term occurs in one paragraph of a document.
+// Paragraph -1 refers to the title and subtitle.
+type Posting struct {
+	Doc       int `json:"d"`
+	Paragraph int `json:"p"`
+	Count     int `json:"c"`
+}
+
+// Index is an inverted index over article bodies.
+type Index struct {
+	Version  int                  `json:"version"`
+	BuiltAt  time.Time            `json:"built_at"`
+	Docs     []Document           `json:"docs"`
+	Postings map[string][]Posting `json:"postings"`
+}
+
+// Result is a ranked search hit with the best-matching paragraph.
+type Result struct {
+	Document
+	Score   float64
+	Snippet string
+}
+
+// Path returns the on-disk location of the index.
+func Path() string {
+	return filepath.Join(config.ConfigDir(), indexFileName)
+}
+
+// New builds an in-memory index from articles.
+func New(articles []*article.Article) *Index {
+	ix := &Index{Version: indexVersion}
+	for _, art := range articles {
+		ix.Docs = append(ix.Docs, newDocument(art, false, time.Time{}))
+	}
+	ix.rebuildPostings()
+	return ix
+}
+
+// Load reads the index from disk.
+func Load() (*Index, bool, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var ix Index
+	if err := json.Unmarshal(data, &ix); err != nil || ix.Version != indexVersion {
+		return nil, false, nil
+	}
+	return &ix, true, nil
+}
+
+// Update brings the on-disk index in line with the cache and library: new or
+// refreshed articles are indexed and those in neither are dropped.
+func Update() (*Index, error) {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ix, ok, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		ix = &Index{Version: indexVersion}
+	}
+	return update(ix)
+}
+
+// Rebuild discards the existing index and indexes the cache and library from scratch.
+func Rebuild() (*Index, error) {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return update(&Index{Version: indexVersion})
+}
+
+func update(ix *Index) (*Index, error) {
+	saved, err := library.List()
+	if err != nil {
+		return nil, err
+	}
+	cached, err := cache.ListArticles()
+	if err != nil {
+		return nil, err
+	}
+
+	inLibrary := make(map[string]bool, len(saved))
+	present := make(map[string]bool, len(saved)+len(cached))
+	for _, entry := range saved {
+		inLibrary[entry.Article.URL] = true
+		present[entry.Article.URL] = true
+	}
+	for _, entry := range cached {
+		present[entry.Article.URL] = true
+	}
+
+	docs := ix.Docs[:0]
+	positions := make(map[string]int, len(ix.Docs))
+	for _, doc := range ix.Docs {
+		if present[doc.URL] {
+			positions[doc.URL] = len(docs)
+			docs = append(docs, doc)
+		}
+	}
+	changed := len(docs) < len(ix.Docs)
+	ix.Docs = docs
+
+	upsert := func(art *article.Article, at time.Time) {
+		if art.URL == "" || art.Content == "" {
+			return
+		}
+		saved := inLibrary[art.URL]
+		if i, ok := positions[art.URL]; ok {
+			existing := &ix.Docs[i]
+			if at.After(existing.IndexedAt) {
+				*existing = newDocument(art, saved, at)
+				changed = true
+			} else if existing.Saved != saved {
+				existing.Saved = saved
+				changed = true
+			}
+			return
+		}
+		positions[art.URL] = len(ix.Docs)
+		ix.Docs = append(ix.Docs, newDocument(art, saved, at))
+		changed = true
+	}
+	for i := range saved {
+		upsert(&saved[i].Article, saved[i].SavedAt)
+	}
+	for i := range cached {
+		upsert(&cached[i].Article, cached[i].CachedAt)
+	}
+
+	if !changed && ix.Postings != nil {
+		return ix, nil
+	}
+
+	ix.rebuildPostings()
+	ix.BuiltAt = time.Now().UTC()
+	return ix, ix.save()
+}
+
+func (ix *Index) save() error {
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(Path(), data)
+}
+
+func newDocument(art *article.Article, saved bool, at time.Time) Document {
+	var paragraphs []string
+	for _, para := range strings.Split(art.Content, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			paragraphs = append(paragraphs, para)
+		}
+	}
+	return Document{
+		URL:        art.URL,
+		Title:      art.Title,
+		Subtitle:   art.Subtitle,
+		DateLine:   art.DateLine,
+		Paragraphs: paragraphs,
+		Saved:      saved,
+		IndexedAt:  at,
+	}
+}
+
+func (ix *Index) rebuildPostings() {
+	ix.Postings = make(map[string][]Posting)
+	for docID := range ix.Docs {
+		doc := &ix.Docs[docID]
+		doc.Length = 0
+		doc.Length += ix.addPostings(docID, titleParagraph, doc.Title+" "+doc.Subtitle)
+		for paraID, para := range doc.Paragraphs {
+			doc.Length += ix.addPostings(docID, paraID, para)
+		}
+	}
+}
+
+func (ix *Index) addPostings(docID, paraID int, text string) int {
+	tokens := Tokenize(text)
+	counts := make(map[string]int)
+	for _, token := range tokens {
+		counts[token]++
+	}
+	for term, count := range counts {
+		ix.Postings[term] = append(ix.Postings[term], Posting{Doc: docID, Paragraph: paraID, Count: count})
+	}
+	return len(tokens)
+}
+
+// Search returns documents containing every query term, best match first.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := uniqueTerms(Tokenize(query))
+	if ix == nil || len(terms) == 0 || len(ix.Docs) == 0 {
+		return nil
+	}
+
+	type docHits struct {
+		score      float64
+		terms      int
+		paragraphs map[int]int
+	}
+
+	hits := make(map[int]*docHits)
+	for _, term := range terms {
+		postings := ix.Postings[term]
+		if len(postings) == 0 {
+			return nil
+		}
+		docFreq := countDocs(postings)
+		idf := math.Log(1 + float64(len(ix.Docs))/float64(docFreq))
+		seen := make(map[int]bool)
+		for _, posting := range postings {
+			hit := hits[posting.Doc]
+			if hit == nil {
+				hit = &docHits{paragraphs: make(map[int]int)}
+				hits[posting.Doc] = hit
+			}
+			weight := 1.0
+			if posting.Paragraph == titleParagraph {
+				weight = titleWeight
+			}
+			hit.score += weight * float64(posting.Count) * idf
+			hit.paragraphs[posting.Paragraph]++
+			if !seen[posting.Doc] {
+				seen[posting.Doc] = true
+				hit.terms++
+			}
+		}
+	}
+
+	var results []Result
+	for docID, hit := range hits {
+		if hit.terms < len(terms) {
+			continue
+		}
+		doc := ix.Docs[docID]
+		length := math.Max(float64(doc.Length), 1)
+		results = append(results, Result{
+			Document: doc,
+			Score:    hit.score / math.Sqrt(length),
+			Snippet:  snippet(doc, bestParagraph(hit.paragraphs), terms),
+		})
+	}
+
+	sortResults(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Match returns documents matching any OR branch of q and none of its
+// exclusions, best match first. Quoted phrases must appear as consecutive
+// words in the title or one paragraph.
+func (ix *Index) Match(q search.Query, limit int) []Result {
+	best := make(map[string]Result)
+	for _, branch := range q.Branches() {
+		for _, result := range ix.matchTerms(branch) {
+			if prev, ok := best[result.URL]; !ok || result.Score > prev.Score {
+				best[result.URL] = result
+			}
+		}
+	}
+	for _, terms := range q.Exclusions() {
+		for _, result := range ix.matchTerms(terms) {
+			delete(best, result.URL)
+		}
+	}
+
+	results := make([]Result, 0, len(best))
+	for _, result := range best {
+		results = append(results, result)
+	}
+	sortResults(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchTerms returns the documents holding every term, where a term of
+// several words is a phrase.
+func (ix *Index) matchTerms(terms []string) []Result {
+	var phrases [][]string
+	for _, term := range terms {
+		if words := Tokenize(term); len(words) > 1 {
+			phrases = append(phrases, words)
+		}
+	}
+	results := ix.Search(strings.Join(terms, " "), 0)
+	if len(phrases) == 0 {
+		return results
+	}
+	matched := results[:0]
+	for _, result := range results {
+		if hasPhrases(result.Document, phrases) {
+			matched = append(matched, result)
+		}
+	}
+	return matched
+}
+
+func hasPhrases(doc Document, phrases [][]string) bool {
+	texts := append([]string{doc.Title + " " + doc.Subtitle}, doc.Paragraphs...)
+	for _, phrase := range phrases {
+		found := false
+		for _, text := range texts {
+			if found = containsRun(Tokenize(text), phrase); found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsRun(words, run []string) bool {
+	for i := 0; i+len(run) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(run)], run) {
+			return true
+		}
+	}
+	return false
+}
+
+func countDocs(postings []Posting) int {
+	seen := make(map[int]bool)
+	for _, posting := range postings {
+		seen[posting.Doc] = true
+	}
+	return len(seen)
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].URL < results[j].URL
+	})
+}
+
+// bestParagraph picks the body paragraph matching the most distinct terms,
+// preferring earlier paragraphs on ties.
+func bestParagraph(paragraphs map[int]int) int {
+	best := titleParagraph
+	bestCount := 0
+	for para, count := range paragraphs {
+		if para == titleParagraph {
+			continue
+		}
+		if count > bestCount || (count == bestCount && para < best) {
+			best = para
+			bestCount = count
+		}
+	}
+	return best
+}
+
+func snippet(doc Document, para int, terms []string) string {
+	if para < 0 || para >= len(doc.Paragraphs) {
+		if len(doc.Paragraphs) == 0 {
+			return doc.Subtitle
+		}
+		para = 0
+	}
+	text := doc.Paragraphs[para]
+	if len(text) <= snippetLen {
+		return text
+	}
+
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+	start := 0
+	for _, tok := range tokenize(text) {
+		if wanted[tok.term] {
+			start = tok.offset
+			break
+		}
+	}
+	if start < snippetLen/3 {
+		start = 0
+	} else {
+		start -= snippetLen / 3
+		for start > 0 && text[start-1] != ' ' {
+			start--
+		}
+	}
+
+	end := start + snippetLen
+	if end >= len(text) {
+		end = len(text)
+	} else {
+		for end > start && text[end] != ' ' {
+			end--
+		}
+	}
+
+	out := strings.TrimSpace(text[start:end])
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(text) {
+		out += "…"
+	}
+	return out
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "in": true, "is": true, "it": true, "its": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "were": true, "which": true, "with": true,
+}
+
+// Tokenize lowercases text and splits it into indexable terms.
+func Tokenize(text string) []string {
+	var terms []string
+	for _, tok := range tokenize(text) {
+		terms = append(terms, tok.term)
+	}
+	return terms
+}
+
+// token is an indexable term and the byte offset of its word in the text.
+type token struct {
+	term   string
+	offset int
+}
+
+// tokenize splits text into words of letters and digits, lowercases them and
+// drops stop words.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			if term := strings.ToLower(text[start:i]); !stopWords[term] {
+				tokens = append(tokens, token{term: term, offset: start})
+			}
+			start = -1
+		}
+	}
+	return tokens
+}
+
+func uniqueTerms(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	var terms []string
+	for _, token := range tokens {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		terms = append(terms, token)
+	}
+	return terms
+}
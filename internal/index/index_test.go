@@ -0,0 +1,199 @@
+package index
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+func setTempHome(t *testing.T) string {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	return tmp
+}
+
+func TestSearchRanksAndSnippets(t *testing.T) {
+	ix := New([]*article.Article{
+		{
+			URL:     "https://example.com/rates",
+			Title:   "Interest rates",
+			Content: "Central banks raised rates again.\n\nInflation in Japan remains stubborn despite tighter policy.",
+		},
+		{
+			URL:     "https://example.com/japan",
+			Title:   "Japan's inflation puzzle",
+			Content: "Prices are rising.\n\nJapan has seen inflation return after decades of deflation.",
+		},
+		{
+			URL:     "https://example.com/other",
+			Title:   "Unrelated",
+			Content: "Nothing about the topic here at all.",
+		},
+	})
+
+	results := ix.Search("Japan inflation", 10)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].URL != "https://example.com/japan" {
+		t.Fatalf("expected title match ranked first, got %q", results[0].URL)
+	}
+	if !strings.Contains(results[1].Snippet, "Inflation in Japan") {
+		t.Fatalf("expected matching paragraph as snippet, got %q", results[1].Snippet)
+	}
+
+	if got := ix.Search("japan deflation", 10); len(got) != 1 {
+		t.Fatalf("expected all terms required, got %d results", len(got))
+	}
+	if got := ix.Search("missing", 10); len(got) != 0 {
+		t.Fatalf("expected no results, got %d", len(got))
+	}
+}
+
+func TestSnippetTrimsLongParagraphs(t *testing.T) {
+	long := strings.Repeat("filler words here ", 40) + "needle appears late " + strings.Repeat("more words ", 40)
+	ix := New([]*article.Article{{URL: "https://example.com/long", Title: "Long", Content: long}})
+
+	results := ix.Search("needle", 1)
+	if len(results) != 1 {
+		t.Fatalf("expected a result")
+	}
+	snippet := results[0].Snippet
+	if !strings.Contains(snippet, "needle") {
+		t.Fatalf("expected snippet around match, got %q", snippet)
+	}
+	if len(snippet) > snippetLen+len("……") {
+		t.Fatalf("expected trimmed snippet, got %d bytes", len(snippet))
+	}
+}
+
+func TestSnippetFindsMatchAfterCaseFoldedText(t *testing.T) {
+	// "Ⱥ" lowercases to three bytes from two, shifting offsets in the
+	// lowercased text past the match.
+	long := strings.Repeat("Ⱥ ", 100) + "needle appears late " + strings.Repeat("more words ", 40)
+	ix := New([]*article.Article{{URL: "https://example.com/long", Title: "Long", Content: long}})
+
+	results := ix.Search("needle", 1)
+	if len(results) != 1 {
+		t.Fatalf("expected a result")
+	}
+	if !strings.Contains(results[0].Snippet, "needle") {
+		t.Fatalf("expected snippet around match, got %q", results[0].Snippet)
+	}
+}
+
+func TestSnippetMatchesWholeWords(t *testing.T) {
+	long := strings.Repeat("filler words here ", 10) + "officials said " + strings.Repeat("more words ", 40) +
+		"the AI boom continues " + strings.Repeat("more words ", 40)
+	ix := New([]*article.Article{{URL: "https://example.com/ai", Title: "Chips", Content: long}})
+
+	results := ix.Search("ai", 1)
+	if len(results) != 1 {
+		t.Fatalf("expected a result")
+	}
+	if !strings.Contains(results[0].Snippet, "AI boom") {
+		t.Fatalf("expected snippet around the word, not inside \"said\", got %q", results[0].Snippet)
+	}
+}
+
+func TestUpdateIndexesCacheAndLibrary(t *testing.T) {
+	setTempHome(t)
+	if err := cache.SaveArticle(&article.Article{URL: "https://example.com/cached", Title: "Cached", Content: "A paragraph about tariffs."}); err != nil {
+		t.Fatalf("cache save: %v", err)
+	}
+	if err := library.Save(&article.Article{URL: "https://example.com/saved", Title: "Saved", Content: "A paragraph about semiconductors."}); err != nil {
+		t.Fatalf("library save: %v", err)
+	}
+
+	if _, err := Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	ix, ok, err := Load()
+	if err != nil || !ok {
+		t.Fatalf("load: ok=%t err=%v", ok, err)
+	}
+	if len(ix.Docs) != 2 {
+		t.Fatalf("expected 2 docs, got %d", len(ix.Docs))
+	}
+
+	results := ix.Search("semiconductors", 10)
+	if len(results) != 1 || !results[0].Saved {
+		t.Fatalf("expected saved article hit, got %#v", results)
+	}
+}
+
+func TestUpdateDropsRemovedArticles(t *testing.T) {
+	setTempHome(t)
+	saved := &article.Article{URL: "https://example.com/saved", Title: "Saved", Content: "A paragraph about semiconductors."}
+	cached := &article.Article{URL: "https://example.com/cached", Title: "Cached", Content: "A paragraph about tariffs."}
+	for _, art := range []*article.Article{saved, cached} {
+		if err := cache.SaveArticle(art); err != nil {
+			t.Fatalf("cache save: %v", err)
+		}
+	}
+	if err := library.Save(saved); err != nil {
+		t.Fatalf("library save: %v", err)
+	}
+	if _, err := Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	if _, err := library.Remove(saved.URL); err != nil {
+		t.Fatalf("library remove: %v", err)
+	}
+	if _, err := cache.RemoveArticles(func(e cache.Entry) bool { return e.Article.URL == cached.URL }); err != nil {
+		t.Fatalf("cache remove: %v", err)
+	}
+	ix, err := Update()
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := ix.Search("tariffs", 10); len(got) != 0 {
+		t.Fatalf("expected article removed from the cache dropped, got %#v", got)
+	}
+	if got := ix.Search("semiconductors", 10); len(got) != 1 || got[0].Saved {
+		t.Fatalf("expected article removed from the library no longer saved, got %#v", got)
+	}
+
+	if _, err := cache.RemoveArticles(func(cache.Entry) bool { return true }); err != nil {
+		t.Fatalf("cache clear: %v", err)
+	}
+	if ix, err = Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(ix.Docs) != 0 || len(ix.Search("semiconductors", 10)) != 0 {
+		t.Fatalf("expected index emptied with the cache and library, got %d docs", len(ix.Docs))
+	}
+}
+
+func TestMatchHonoursOrExclusionsAndPhrases(t *testing.T) {
+	ix := New([]*article.Article{
+		{URL: "https://example.com/china", Title: "China", Content: "China's exports rose. A rate cut followed."},
+		{URL: "https://example.com/india", Title: "India", Content: "India's exports rose despite tariffs."},
+		{URL: "https://example.com/cut", Title: "Cuts", Content: "The rate was left alone; spending was cut."},
+	})
+	urls := func(results []Result) []string {
+		var out []string
+		for _, result := range results {
+			out = append(out, result.URL)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	if got := urls(ix.Match(search.Parse("china OR india"), 0)); len(got) != 2 {
+		t.Fatalf("expected either term to match, got %v", got)
+	}
+	if got := urls(ix.Match(search.Parse("exports -tariffs"), 0)); len(got) != 1 || got[0] != "https://example.com/china" {
+		t.Fatalf("expected excluded body dropped, got %v", got)
+	}
+	if got := urls(ix.Match(search.Parse(`"rate cut"`), 0)); len(got) != 1 || got[0] != "https://example.com/china" {
+		t.Fatalf("expected phrase to need consecutive words, got %v", got)
+	}
+}
@@ -0,0 +1,119 @@
+package library
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+	"github.com/tmustier/economist-tui/internal/config"
+)
+
+const libraryDirName = "library"
+
+// Entry is a saved article. Unlike cache entries, entries never expire.
+type Entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Article article.Article `json:"article"`
+}
+
+func Load(url string) (*article.Article, bool, error) {
+	entry, ok, err := loadEntry(entryPath(url))
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return &entry.Article, true, nil
+}
+
+func Save(art *article.Article) error {
+	saved := *art
+	saved.DebugHTMLPath = ""
+	entry := Entry{
+		SavedAt: time.Now().UTC(),
+		Article: saved,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(entryPath(art.URL), data)
+}
+
+func Has(url string) bool {
+	_, err := os.Stat(entryPath(url))
+	return err == nil
+}
+
+func Remove(url string) (bool, error) {
+	err := os.Remove(entryPath(url))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns saved articles, most recently saved first.
+func List() ([]Entry, error) {
+	dir := Dir()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		entry, ok, err := loadEntry(filepath.Join(dir, file.Name()))
+		if err != nil || !ok {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].SavedAt.After(entries[j].SavedAt)
+	})
+	return entries, nil
+}
+
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), libraryDirName)
+}
+
+func loadEntry(path string) (*Entry, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, err
+	}
+	return &entry, true, nil
+}
+
+func entryPath(url string) string {
+	h := sha1.Sum([]byte(url))
+	name := hex.EncodeToString(h[:]) + ".json"
+	return filepath.Join(Dir(), name)
+}
@@ -0,0 +1,84 @@
+package library
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+)
+
+func setTempHome(t *testing.T) string {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	return tmp
+}
+
+func TestSaveLoadRemove(t *testing.T) {
+	setTempHome(t)
+	art := &article.Article{URL: "https://example.com/saved", Title: "Saved", DebugHTMLPath: "/tmp/x.html"}
+
+	if err := Save(art); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if !Has(art.URL) {
+		t.Fatalf("expected saved article to exist")
+	}
+
+	loaded, ok, err := Load(art.URL)
+	if err != nil || !ok {
+		t.Fatalf("load: ok=%t err=%v", ok, err)
+	}
+	if loaded.Title != art.Title {
+		t.Fatalf("expected title %q, got %q", art.Title, loaded.Title)
+	}
+	if loaded.DebugHTMLPath != "" {
+		t.Fatalf("expected debug path stripped, got %q", loaded.DebugHTMLPath)
+	}
+
+	removed, err := Remove(art.URL)
+	if err != nil || !removed {
+		t.Fatalf("remove: removed=%t err=%v", removed, err)
+	}
+	if _, ok, _ := Load(art.URL); ok {
+		t.Fatalf("expected article removed")
+	}
+}
+
+func TestListNewestFirst(t *testing.T) {
+	setTempHome(t)
+	for _, url := range []string{"https://example.com/a", "https://example.com/b"} {
+		if err := Save(&article.Article{URL: url}); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Article.URL != "https://example.com/b" {
+		t.Fatalf("expected newest first, got %q", entries[0].Article.URL)
+	}
+}
+
+func TestSurvivesCachePurge(t *testing.T) {
+	setTempHome(t)
+	art := &article.Article{URL: "https://example.com/keep"}
+	if err := Save(art); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := cache.PurgeExpired(); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if _, err := os.Stat(entryPath(art.URL)); err != nil {
+		t.Fatalf("expected saved article retained: %v", err)
+	}
+}
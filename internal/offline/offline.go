@@ -0,0 +1,103 @@
+// Package offline tracks whether this process should avoid the network.
+package offline
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// RetryAfter is how long a network failure keeps offline mode on before the
+// next request tries the network again.
+const RetryAfter = 30 * time.Second
+
+var (
+	enabled atomic.Bool
+	// failedAt is when NoteFailure last saw the network down, in Unix
+	// nanoseconds, or zero.
+	failedAt atomic.Int64
+)
+
+// Enable switches the process to offline mode: feeds and articles are served
+// only from the cache and the library.
+func Enable() {
+	enabled.Store(true)
+}
+
+// Disable turns offline mode off again, including after a network failure.
+func Disable() {
+	enabled.Store(false)
+	failedAt.Store(0)
+}
+
+// Enabled reports whether offline mode is on, either by Enable or for
+// RetryAfter after a network failure.
+func Enabled() bool {
+	if enabled.Load() {
+		return true
+	}
+	at := failedAt.Load()
+	return at != 0 && time.Since(time.Unix(0, at)) < RetryAfter
+}
+
+// NoteFailure turns offline mode on for RetryAfter if err shows the network
+// is unreachable, so later requests fail fast instead of timing out one by
+// one. It reports whether err was a network failure.
+func NoteFailure(err error) bool {
+	if !IsNetworkError(err) {
+		return false
+	}
+	failedAt.Store(time.Now().UnixNano())
+	return true
+}
+
+// chromeNetworkErrors are the Chrome net errors that mean there is no usable
+// connection, as opposed to a problem with one site.
+var chromeNetworkErrors = []string{
+	"net::ERR_INTERNET_DISCONNECTED",
+	"net::ERR_NAME_NOT_RESOLVED",
+	"net::ERR_NAME_RESOLUTION_FAILED",
+	"net::ERR_NETWORK_CHANGED",
+	"net::ERR_ADDRESS_UNREACHABLE",
+	"net::ERR_PROXY_CONNECTION_FAILED",
+}
+
+// unreachableErrnos are the dial errors that mean a remote host could not be
+// reached at all.
+var unreachableErrnos = []error{
+	syscall.ECONNREFUSED,
+	syscall.ENETUNREACH,
+	syscall.EHOSTUNREACH,
+}
+
+// IsNetworkError reports whether err means the network could not be reached:
+// DNS failures, refused or unreachable dials to a remote host, and Chrome's
+// equivalents. Dials to a local socket, such as the daemon's, and timeouts
+// are not network failures.
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Net != "unix" {
+		for _, errno := range unreachableErrnos {
+			if errors.Is(opErr.Err, errno) {
+				return true
+			}
+		}
+	}
+	msg := err.Error()
+	for _, code := range chromeNetworkErrors {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
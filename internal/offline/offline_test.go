@@ -0,0 +1,75 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsNetworkError(t *testing.T) {
+	network := []error{
+		&url.Error{Op: "Get", URL: "https://www.economist.com/leaders/rss.xml", Err: &net.DNSError{Err: "no such host", Name: "www.economist.com"}},
+		&url.Error{Op: "Get", URL: "https://www.economist.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}},
+		&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)},
+		fmt.Errorf("failed to load page: page load error net::ERR_INTERNET_DISCONNECTED"),
+	}
+	for _, err := range network {
+		if !IsNetworkError(err) {
+			t.Fatalf("expected network error: %v", err)
+		}
+	}
+
+	other := []error{
+		nil,
+		context.Canceled,
+		context.DeadlineExceeded,
+		&url.Error{Op: "Get", URL: "https://www.economist.com", Err: timeoutError{}},
+		&net.OpError{Op: "dial", Net: "unix", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+		errors.New("HTTP 404 from https://www.economist.com/nope/rss.xml"),
+		errors.New("no article content found"),
+	}
+	for _, err := range other {
+		if IsNetworkError(err) {
+			t.Fatalf("expected non-network error: %v", err)
+		}
+	}
+}
+
+func TestNoteFailureEnablesOfflineMode(t *testing.T) {
+	t.Cleanup(Disable)
+
+	if NoteFailure(errors.New("HTTP 500")) || Enabled() {
+		t.Fatalf("expected server errors to leave offline mode off")
+	}
+	if !NoteFailure(&net.DNSError{Err: "no such host"}) || !Enabled() {
+		t.Fatalf("expected a DNS failure to enable offline mode")
+	}
+
+	failedAt.Store(time.Now().Add(-RetryAfter - time.Second).UnixNano())
+	if Enabled() {
+		t.Fatalf("expected offline mode to end after RetryAfter")
+	}
+}
+
+func TestEnableOutlastsRetry(t *testing.T) {
+	t.Cleanup(Disable)
+
+	Enable()
+	failedAt.Store(time.Now().Add(-RetryAfter - time.Second).UnixNano())
+	if !Enabled() {
+		t.Fatalf("expected offline mode to stay on once enabled")
+	}
+}
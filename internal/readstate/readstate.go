@@ -0,0 +1,172 @@
+package readstate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+	"github.com/tmustier/economist-tui/internal/config"
+)
+
+const (
+	stateFileName = "read.json"
+
+	// forgetAfter is how long read marks and positions are kept. Items are
+	// long gone from the feeds by then.
+	forgetAfter = 180 * 24 * time.Hour
+)
+
+// State records when articles were first opened and where reading stopped.
+// Read keys are item identifiers as returned by rss.Item.Keys, so an article
+// counts as read whether it is matched by GUID or by link. Positions are
+// keyed by article URL.
+type State struct {
+	Read      map[string]time.Time `json:"read"`
+	Positions map[string]Position  `json:"positions,omitempty"`
+}
+
+// Position is where reading stopped in an article. Paragraph is an index into
+// the article body, so it survives re-wrapping; Percent is for display.
+type Position struct {
+	Paragraph int       `json:"paragraph"`
+	Percent   int       `json:"percent"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func Path() string {
+	return filepath.Join(config.ConfigDir(), stateFileName)
+}
+
+// New returns an empty in-memory state.
+func New() *State {
+	return &State{Read: make(map[string]time.Time), Positions: make(map[string]Position)}
+}
+
+// Load reads the state from disk. A missing file yields an empty state.
+func Load() (*State, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(), nil
+		}
+		return nil, err
+	}
+
+	state := New()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Read == nil {
+		state.Read = make(map[string]time.Time)
+	}
+	if state.Positions == nil {
+		state.Positions = make(map[string]Position)
+	}
+	return state, nil
+}
+
+// IsRead reports whether any of keys has been marked read.
+func (s *State) IsRead(keys ...string) bool {
+	if s == nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := s.Read[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Mark records keys as read now, keeping the time of the first read.
+func (s *State) Mark(keys ...string) {
+	now := time.Now().UTC()
+	for _, key := range keys {
+		if _, ok := s.Read[key]; !ok {
+			s.Read[key] = now
+		}
+	}
+}
+
+// Position returns the saved position for url.
+func (s *State) Position(url string) (Position, bool) {
+	if s == nil {
+		return Position{}, false
+	}
+	pos, ok := s.Positions[url]
+	return pos, ok
+}
+
+// SetPosition records pos for url.
+func (s *State) SetPosition(url string, pos Position) {
+	if pos.UpdatedAt.IsZero() {
+		pos.UpdatedAt = time.Now().UTC()
+	}
+	s.Positions[url] = pos
+}
+
+func (s *State) Save() error {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.write()
+}
+
+// write prunes the state and writes it. The caller holds the state lock.
+func (s *State) write() error {
+	s.forget(time.Now())
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(Path(), data)
+}
+
+// forget drops read marks and positions older than forgetAfter, so the state
+// does not grow forever.
+func (s *State) forget(now time.Time) {
+	for key, at := range s.Read {
+		if now.Sub(at) > forgetAfter {
+			delete(s.Read, key)
+		}
+	}
+	for url, pos := range s.Positions {
+		if now.Sub(pos.UpdatedAt) > forgetAfter {
+			delete(s.Positions, url)
+		}
+	}
+}
+
+// MarkRead loads the on-disk state, marks keys read and saves it.
+func MarkRead(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return update(func(state *State) { state.Mark(keys...) })
+}
+
+// SavePosition loads the on-disk state, records pos for url and saves it.
+func SavePosition(url string, pos Position) error {
+	return update(func(state *State) { state.SetPosition(url, pos) })
+}
+
+// update applies change to the on-disk state under the state lock, so
+// concurrent readers and the daemon do not drop each other's writes.
+func update(change func(*State)) error {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := Load()
+	if err != nil {
+		return err
+	}
+	change(state)
+	return state.write()
+}
@@ -0,0 +1,100 @@
+package readstate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarkReadPersists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("load empty: %v", err)
+	}
+	if state.IsRead("link:https://example.com/a") {
+		t.Fatalf("expected empty state")
+	}
+
+	if err := MarkRead("guid:a", "link:https://example.com/a"); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+
+	state, err = Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !state.IsRead("link:https://example.com/a") || !state.IsRead("guid:other", "guid:a") {
+		t.Fatalf("expected marked keys to be read")
+	}
+	if state.IsRead("link:https://example.com/b") {
+		t.Fatalf("expected unmarked key to be unread")
+	}
+}
+
+func TestMarkKeepsFirstReadTime(t *testing.T) {
+	state := New()
+	state.Mark("guid:a")
+	first := state.Read["guid:a"]
+	state.Mark("guid:a")
+	if !state.Read["guid:a"].Equal(first) {
+		t.Fatalf("expected first read time to be kept")
+	}
+
+	var missing *State
+	if missing.IsRead("guid:a") {
+		t.Fatalf("expected nil state to report unread")
+	}
+}
+
+func TestSavePosition(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	url := "https://example.com/a"
+
+	if err := SavePosition(url, Position{Paragraph: 4, Percent: 37}); err != nil {
+		t.Fatalf("save position: %v", err)
+	}
+	if err := MarkRead("link:" + url); err != nil {
+		t.Fatalf("mark: %v", err)
+	}
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	pos, ok := state.Position(url)
+	if !ok || pos.Paragraph != 4 || pos.Percent != 37 || pos.UpdatedAt.IsZero() {
+		t.Fatalf("expected saved position, got %#v ok=%t", pos, ok)
+	}
+	if _, ok := state.Position("https://example.com/b"); ok {
+		t.Fatalf("expected no position for unopened article")
+	}
+}
+
+func TestSaveForgetsOldEntries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	old := time.Now().Add(-forgetAfter - time.Hour)
+	state := New()
+	state.Read["link:https://example.com/old"] = old
+	state.Mark("link:https://example.com/new")
+	state.SetPosition("https://example.com/old", Position{Paragraph: 3, UpdatedAt: old})
+	state.SetPosition("https://example.com/new", Position{Paragraph: 1})
+	if err := state.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if state.IsRead("link:https://example.com/old") || !state.IsRead("link:https://example.com/new") {
+		t.Fatalf("expected only the old read mark dropped, got %v", state.Read)
+	}
+	if _, ok := state.Position("https://example.com/old"); ok {
+		t.Fatalf("expected old position dropped")
+	}
+	if _, ok := state.Position("https://example.com/new"); !ok {
+		t.Fatalf("expected recent position kept")
+	}
+}
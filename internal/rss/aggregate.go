@@ -0,0 +1,135 @@
+package rss
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const maxConcurrentFetches = 6
+
+// SectionItems holds the feed items fetched for one section.
+type SectionItems struct {
+	Section string
+	Items   []Item
+}
+
+// TaggedItem is a feed item along with every section it appeared in.
+type TaggedItem struct {
+	Item
+	Sections []string
+}
+
+// FetchSections fetches several section feeds concurrently and merges them
+// with Merge. Sections that fail are skipped; the returned error describes
+// them and is non-nil whenever any section failed.
+func FetchSections(sections []string) ([]TaggedItem, error) {
+	feeds, err := fetchFeeds(sections)
+	return Merge(feeds), err
+}
+
+func fetchFeeds(sections []string) ([]SectionItems, error) {
+	feeds := make([]SectionItems, len(sections))
+	errs := make([]error, len(sections))
+
+	sem := make(chan struct{}, maxConcurrentFetches)
+	var wg sync.WaitGroup
+	for i, section := range sections {
+		wg.Add(1)
+		go func(i int, section string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			feed, err := FetchSection(section)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", section, err)
+				return
+			}
+			feeds[i] = SectionItems{Section: section, Items: feed.Channel.Items}
+		}(i, section)
+	}
+	wg.Wait()
+
+	return feeds, errors.Join(errs...)
+}
+
+// AllSections returns the primary alias of every known section.
+func AllSections() []string {
+	infos := SectionList()
+	sections := make([]string, 0, len(infos))
+	for _, info := range infos {
+		sections = append(sections, info.Primary)
+	}
+	return sections
+}
+
+// Merge combines section feeds, dedupes items by GUID or link, and sorts
+// them newest first. Items without a parseable date sort last.
+func Merge(feeds []SectionItems) []TaggedItem {
+	var merged []TaggedItem
+	byKey := make(map[string]int)
+
+	for _, feed := range feeds {
+		for _, item := range feed.Items {
+			keys := item.Keys()
+			idx, found := -1, false
+			for _, key := range keys {
+				if idx, found = byKey[key]; found {
+					break
+				}
+			}
+			if !found {
+				idx = len(merged)
+				merged = append(merged, TaggedItem{Item: item})
+			}
+			for _, key := range keys {
+				byKey[key] = idx
+			}
+			if !containsString(merged[idx].Sections, feed.Section) {
+				merged[idx].Sections = append(merged[idx].Sections, feed.Section)
+			}
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		ti, tj := merged[i].Published(), merged[j].Published()
+		if ti.IsZero() != tj.IsZero() {
+			return tj.IsZero()
+		}
+		return ti.After(tj)
+	})
+	return merged
+}
+
+// RankTagged filters merged items by query and orders them by score. The
+// section: filter matches any of an item's sections.
+func RankTagged(items []TaggedItem, query string) []TaggedItem {
+	docs := make([]Item, len(items))
+	sections := make([]string, len(items))
+	for i, item := range items {
+		docs[i] = item.Item
+		paths := make([]string, 0, len(item.Sections))
+		for _, section := range item.Sections {
+			paths = append(paths, resolveSection(section))
+		}
+		sections[i] = strings.Join(paths, " ")
+	}
+
+	ranked := make([]TaggedItem, 0, len(items))
+	for _, idx := range rankIndices(docs, sections, query) {
+		ranked = append(ranked, items[idx])
+	}
+	return ranked
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package rss
+
+import "testing"
+
+func TestMergeDedupesAndSorts(t *testing.T) {
+	feeds := []SectionItems{
+		{Section: "finance", Items: []Item{
+			{Title: "Old", Link: "https://e.com/old", GUID: "old", PubDate: "Mon, 05 Jan 2026 10:00:00 +0000"},
+			{Title: "Shared", Link: "https://e.com/shared", GUID: "shared", PubDate: "Wed, 07 Jan 2026 10:00:00 +0000"},
+		}},
+		{Section: "business", Items: []Item{
+			{Title: "Shared", Link: "https://e.com/shared", PubDate: "Wed, 07 Jan 2026 10:00:00 +0000"},
+			{Title: "Undated", Link: "https://e.com/undated"},
+			{Title: "New", Link: "https://e.com/new", GUID: "new", PubDate: "Thu, 08 Jan 2026 10:00:00 +0000"},
+		}},
+	}
+
+	merged := Merge(feeds)
+	if len(merged) != 4 {
+		t.Fatalf("expected 4 items after dedupe, got %d", len(merged))
+	}
+
+	order := []string{"New", "Shared", "Old", "Undated"}
+	for i, title := range order {
+		if merged[i].Title != title {
+			t.Fatalf("position %d: expected %q, got %q", i, title, merged[i].Title)
+		}
+	}
+
+	shared := merged[1]
+	if len(shared.Sections) != 2 || shared.Sections[0] != "finance" || shared.Sections[1] != "business" {
+		t.Fatalf("expected shared item tagged with both sections, got %v", shared.Sections)
+	}
+}
+
+func TestRankTaggedMatchesAnySection(t *testing.T) {
+	items := []TaggedItem{
+		{Item: Item{Title: "Banks", Link: "a"}, Sections: []string{"finance", "business"}},
+		{Item: Item{Title: "Banks", Link: "b"}, Sections: []string{"britain"}},
+	}
+
+	ranked := RankTagged(items, "banks section:business")
+	if len(ranked) != 1 || ranked[0].Link != "a" {
+		t.Fatalf("expected only business item, got %#v", ranked)
+	}
+}
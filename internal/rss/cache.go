@@ -1,68 +1,32 @@
 package rss
 
 import (
-	"crypto/sha1"
-	"encoding/hex"
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"time"
+	"net/http"
 
 	"github.com/tmustier/economist-tui/internal/cache"
 )
 
-const rssCacheTTL = 120 * time.Second
-const rssCachePrefix = "rss-"
-
-type rssCacheEntry struct {
-	CachedAt time.Time `json:"cached_at"`
-	Body     []byte    `json:"body"`
-}
-
-func loadCachedSection(sectionPath string) ([]byte, time.Time, bool, error) {
-	path := rssCachePath(sectionPath)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, time.Time{}, false, nil
-		}
-		return nil, time.Time{}, false, err
+// loadCachedSection returns the cached feed for sectionPath and its parsed
+// items, if the cache holds a copy that still parses.
+func loadCachedSection(sectionPath string) (*cache.FeedEntry, *RSS, bool) {
+	entry, ok, err := cache.LoadFeed(sectionPath)
+	if err != nil || !ok {
+		return nil, nil, false
 	}
-
-	var entry rssCacheEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
-		_ = os.Remove(path)
-		return nil, time.Time{}, false, err
-	}
-
-	return entry.Body, entry.CachedAt, true, nil
-}
-
-func saveCachedSection(sectionPath string, body []byte) error {
-	if err := os.MkdirAll(rssCacheDir(), 0755); err != nil {
-		return err
-	}
-
-	entry := rssCacheEntry{
-		CachedAt: time.Now().UTC(),
-		Body:     body,
-	}
-
-	data, err := json.Marshal(entry)
+	feed, err := parseRSS(entry.Body)
 	if err != nil {
-		return err
+		return nil, nil, false
 	}
-
-	path := rssCachePath(sectionPath)
-	return os.WriteFile(path, data, 0600)
-}
-
-func rssCacheDir() string {
-	return cache.CacheDir()
+	return entry, feed, true
 }
 
-func rssCachePath(sectionPath string) string {
-	hash := sha1.Sum([]byte(sectionPath))
-	name := rssCachePrefix + hex.EncodeToString(hash[:]) + ".json"
-	return filepath.Join(rssCacheDir(), name)
+// saveCachedSection caches body with the validators from header, which may
+// be nil.
+func saveCachedSection(sectionPath string, body []byte, header http.Header) error {
+	entry := cache.FeedEntry{Section: sectionPath, Body: body}
+	if header != nil {
+		entry.ETag = header.Get("ETag")
+		entry.LastModified = header.Get("Last-Modified")
+	}
+	return cache.SaveFeed(entry)
 }
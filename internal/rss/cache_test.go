@@ -0,0 +1,131 @@
+package rss
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/offline"
+)
+
+const testFeed = `<?xml version="1.0"?><rss><channel><title>Leaders</title>
+<item><title>Cached headline</title><link>https://www.economist.com/leaders/a</link></item>
+</channel></rss>`
+
+func TestFetchSectionOfflineUsesStaleCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	offline.Enable()
+	t.Cleanup(offline.Disable)
+
+	if _, err := FetchSection("leaders"); err == nil {
+		t.Fatalf("expected an error for an uncached section offline")
+	}
+
+	if err := saveCachedSection("leaders", []byte(testFeed), nil); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	feed, err := FetchSection("leaders")
+	if err != nil {
+		t.Fatalf("fetch offline: %v", err)
+	}
+	if len(feed.Channel.Items) != 1 || feed.Channel.Items[0].Title != "Cached headline" {
+		t.Fatalf("expected cached feed, got %#v", feed.Channel.Items)
+	}
+}
+
+func TestFetchSectionRevalidatesWithETag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache.Configure(cache.Settings{RSSTTL: time.Nanosecond})
+	t.Cleanup(func() { cache.Configure(cache.DefaultSettings()) })
+
+	const lastModified = "Sat, 24 Jan 2026 08:00:00 GMT"
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/leaders/rss.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == lastModified {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer server.Close()
+
+	oldBase := baseURL
+	baseURL = server.URL
+	t.Cleanup(func() { baseURL = oldBase })
+
+	if _, err := FetchSection("leaders"); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	first, ok, err := cache.LoadFeed("leaders")
+	if err != nil || !ok || first.ETag != `"v1"` || first.LastModified != lastModified {
+		t.Fatalf("expected validators cached, got %+v ok=%v err=%v", first, ok, err)
+	}
+
+	time.Sleep(time.Millisecond)
+	feed, err := FetchSection("leaders")
+	if err != nil {
+		t.Fatalf("second fetch: %v", err)
+	}
+	if len(feed.Channel.Items) != 1 || feed.Channel.Items[0].Title != "Cached headline" {
+		t.Fatalf("expected cached items after 304, got %#v", feed.Channel.Items)
+	}
+	if atomic.LoadInt32(&requests) != 2 || atomic.LoadInt32(&notModified) != 1 {
+		t.Fatalf("expected a conditional second request answered with 304, got %d requests, %d not modified", requests, notModified)
+	}
+	refreshed, _, _ := cache.LoadFeed("leaders")
+	if !refreshed.CachedAt.After(first.CachedAt) || refreshed.ETag != `"v1"` {
+		t.Fatalf("expected 304 to refresh the cache entry, got %+v", refreshed)
+	}
+}
+
+func TestFetchSectionKeepsLastModifiedFrom304(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache.Configure(cache.Settings{RSSTTL: time.Nanosecond})
+	t.Cleanup(func() { cache.Configure(cache.DefaultSettings()) })
+
+	const (
+		first  = "Sat, 24 Jan 2026 08:00:00 GMT"
+		second = "Sat, 24 Jan 2026 09:00:00 GMT"
+	)
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since := r.Header.Get("If-Modified-Since")
+		sent = append(sent, since)
+		if since == "" {
+			w.Header().Set("Last-Modified", first)
+			_, _ = w.Write([]byte(testFeed))
+			return
+		}
+		w.Header().Set("Last-Modified", second)
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	oldBase := baseURL
+	baseURL = server.URL
+	t.Cleanup(func() { baseURL = oldBase })
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+		if _, err := FetchSection("leaders"); err != nil {
+			t.Fatalf("fetch %d: %v", i+1, err)
+		}
+	}
+	if len(sent) != 3 || sent[1] != first || sent[2] != second {
+		t.Fatalf("expected the Last-Modified from the 304 sent next, got %q", sent)
+	}
+	if entry, _, _ := cache.LoadFeed("leaders"); entry.LastModified != second {
+		t.Fatalf("expected cached Last-Modified updated, got %q", entry.LastModified)
+	}
+}
@@ -0,0 +1,186 @@
+package rss
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// editionSections lists section paths in the order they appear in the print
+// edition.
+var editionSections = []struct {
+	Path  string
+	Title string
+}{
+	{"the-world-this-week", "The world this week"},
+	{"leaders", "Leaders"},
+	{"briefing", "Briefing"},
+	{"united-states", "United States"},
+	{"the-americas", "The Americas"},
+	{"asia", "Asia"},
+	{"china", "China"},
+	{"middle-east-and-africa", "Middle East & Africa"},
+	{"europe", "Europe"},
+	{"britain", "Britain"},
+	{"business", "Business"},
+	{"finance-and-economics", "Finance & economics"},
+	{"science-and-technology", "Science & technology"},
+	{"culture", "Culture"},
+	{"graphic-detail", "Graphic detail"},
+}
+
+// Edition is one weekly issue, with its items grouped by section in print
+// order.
+type Edition struct {
+	Date     time.Time
+	Sections []EditionSection
+}
+
+// EditionSection is one section of an edition.
+type EditionSection struct {
+	Path  string
+	Title string
+	Items []Item
+}
+
+// EditionPaths returns the section paths in print edition order.
+func EditionPaths() []string {
+	paths := make([]string, len(editionSections))
+	for i, section := range editionSections {
+		paths[i] = section.Path
+	}
+	return paths
+}
+
+// SectionTitle returns the print heading of a section, or the section itself
+// when it has none.
+func SectionTitle(section string) string {
+	path := resolveSection(section)
+	for _, s := range editionSections {
+		if s.Path == path {
+			return s.Title
+		}
+	}
+	return section
+}
+
+// IssueDate returns the cover date of the issue that covers t. Issues are
+// dated Saturday and cover the week from the Sunday before.
+func IssueDate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, int(time.Saturday-day.Weekday()))
+}
+
+// ParseIssueDate parses a YYYY-MM-DD date, or "latest", which returns the zero
+// time. Dates inside a week resolve to that week's issue.
+func ParseIssueDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "latest") {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid issue date %q (use YYYY-MM-DD or latest)", value)
+	}
+	return IssueDate(t), nil
+}
+
+// BuildEdition groups feed items published in the week of issue into print
+// order. A zero issue picks the newest issue any item belongs to. Items in
+// several sections are listed under the first one in print order.
+func BuildEdition(feeds []SectionItems, issue time.Time) Edition {
+	ordered := make([]SectionItems, len(feeds))
+	copy(ordered, feeds)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return editionRank(ordered[i].Section) < editionRank(ordered[j].Section)
+	})
+	merged := Merge(ordered)
+
+	if issue.IsZero() {
+		for _, item := range merged {
+			if published := item.Published(); !published.IsZero() {
+				issue = IssueDate(published)
+				break
+			}
+		}
+	} else {
+		issue = IssueDate(issue)
+	}
+
+	edition := Edition{Date: issue}
+	if issue.IsZero() {
+		return edition
+	}
+
+	bySection := make(map[string]int)
+	for _, item := range merged {
+		published := item.Published()
+		if published.IsZero() || !IssueDate(published).Equal(issue) || len(item.Sections) == 0 {
+			continue
+		}
+		path := resolveSection(item.Sections[0])
+		idx, ok := bySection[path]
+		if !ok {
+			idx = len(edition.Sections)
+			bySection[path] = idx
+			edition.Sections = append(edition.Sections, EditionSection{Path: path, Title: SectionTitle(path)})
+		}
+		edition.Sections[idx].Items = append(edition.Sections[idx].Items, item.Item)
+	}
+
+	sort.SliceStable(edition.Sections, func(i, j int) bool {
+		return editionRank(edition.Sections[i].Path) < editionRank(edition.Sections[j].Path)
+	})
+	return edition
+}
+
+// FetchEdition fetches every edition section and builds the issue dated
+// issue, or the latest one when issue is zero. Like FetchSections, failed
+// sections are skipped and reported in the error.
+func FetchEdition(issue time.Time) (Edition, error) {
+	feeds, err := fetchFeeds(EditionPaths())
+	return BuildEdition(feeds, issue), err
+}
+
+// Items flattens the edition in print order, tagging each item with its
+// section.
+func (e Edition) Items() []TaggedItem {
+	var items []TaggedItem
+	for _, section := range e.Sections {
+		for _, item := range section.Items {
+			items = append(items, TaggedItem{Item: item, Sections: []string{section.Path}})
+		}
+	}
+	return items
+}
+
+// Len returns the number of items in the edition.
+func (e Edition) Len() int {
+	n := 0
+	for _, section := range e.Sections {
+		n += len(section.Items)
+	}
+	return n
+}
+
+// Title returns the edition heading, such as "Weekly edition: Jan 24th 2026".
+func (e Edition) Title() string {
+	if e.Date.IsZero() {
+		return "Weekly edition"
+	}
+	return "Weekly edition: " + article.FormatDateLine(e.Date)
+}
+
+func editionRank(section string) int {
+	path := resolveSection(section)
+	for i, s := range editionSections {
+		if s.Path == path {
+			return i
+		}
+	}
+	return len(editionSections)
+}
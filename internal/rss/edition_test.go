@@ -0,0 +1,73 @@
+package rss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueDate(t *testing.T) {
+	cases := map[string]string{
+		"Sun, 18 Jan 2026 00:00:00 +0000": "2026-01-24",
+		"Thu, 22 Jan 2026 21:00:00 +0000": "2026-01-24",
+		"Sat, 24 Jan 2026 23:59:00 +0000": "2026-01-24",
+		"Sun, 25 Jan 2026 01:00:00 +0000": "2026-01-31",
+	}
+	for pubDate, want := range cases {
+		got := IssueDate(Item{PubDate: pubDate}.Published()).Format("2006-01-02")
+		if got != want {
+			t.Fatalf("%s: expected issue %s, got %s", pubDate, want, got)
+		}
+	}
+
+	if issue, err := ParseIssueDate("2026-01-21"); err != nil || issue.Format("2006-01-02") != "2026-01-24" {
+		t.Fatalf("expected mid-week date to resolve to its issue, got %v %v", issue, err)
+	}
+	if issue, err := ParseIssueDate("latest"); err != nil || !issue.IsZero() {
+		t.Fatalf("expected latest to be zero, got %v %v", issue, err)
+	}
+	if _, err := ParseIssueDate("24/01/2026"); err == nil {
+		t.Fatalf("expected error for malformed date")
+	}
+}
+
+func TestBuildEditionGroupsInPrintOrder(t *testing.T) {
+	feeds := []SectionItems{
+		{Section: "business", Items: []Item{
+			{Title: "Boardrooms", Link: "https://e.com/boardrooms", PubDate: "Thu, 22 Jan 2026 21:00:00 +0000"},
+			{Title: "Shared", Link: "https://e.com/shared", PubDate: "Thu, 22 Jan 2026 21:00:00 +0000"},
+			{Title: "Last week", Link: "https://e.com/old", PubDate: "Thu, 15 Jan 2026 21:00:00 +0000"},
+		}},
+		{Section: "leaders", Items: []Item{
+			{Title: "Shared", Link: "https://e.com/shared", PubDate: "Thu, 22 Jan 2026 21:00:00 +0000"},
+		}},
+		{Section: "world-this-week", Items: []Item{
+			{Title: "Politics", Link: "https://e.com/politics", PubDate: "Thu, 22 Jan 2026 21:00:00 +0000"},
+		}},
+	}
+
+	edition := BuildEdition(feeds, time.Time{})
+	if got := edition.Date.Format("2006-01-02"); got != "2026-01-24" {
+		t.Fatalf("expected latest issue 2026-01-24, got %s", got)
+	}
+	if edition.Len() != 3 {
+		t.Fatalf("expected 3 items in the issue, got %d", edition.Len())
+	}
+
+	order := []string{"the-world-this-week", "leaders", "business"}
+	if len(edition.Sections) != len(order) {
+		t.Fatalf("expected %d sections, got %#v", len(order), edition.Sections)
+	}
+	for i, path := range order {
+		if edition.Sections[i].Path != path {
+			t.Fatalf("position %d: expected %s, got %s", i, path, edition.Sections[i].Path)
+		}
+	}
+	if edition.Sections[1].Items[0].Title != "Shared" {
+		t.Fatalf("expected shared item under leaders, got %#v", edition.Sections[1].Items)
+	}
+
+	previous := BuildEdition(feeds, time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC))
+	if previous.Len() != 1 || previous.Sections[0].Items[0].Title != "Last week" {
+		t.Fatalf("expected only last week's item, got %#v", previous.Sections)
+	}
+}
@@ -1,9 +1,9 @@
 package rss
 
-// PrefetchAll fetches all known sections to warm the RSS cache.
-func PrefetchAll() {
-	sections := SectionList()
-	for _, info := range sections {
-		_, _ = FetchSection(info.Path)
-	}
+// PrefetchAll fetches all known sections concurrently to warm the RSS cache.
+// Stale feeds are revalidated with conditional requests, so a refresh costs
+// little when nothing has changed.
+func PrefetchAll() error {
+	_, err := FetchSections(AllSections())
+	return err
 }
@@ -5,15 +5,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/tmustier/economist-tui/internal/article"
 	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/offline"
 	"github.com/tmustier/economist-tui/internal/search"
 )
 
 const httpTimeout = 10 * time.Second
 
+// baseURL is where feeds are fetched from. Tests point it at a local server.
+var baseURL = "https://www.economist.com"
+
 // Sections maps aliases to canonical RSS feed paths.
 var Sections = map[string]string{
 	"leaders":               "leaders",
@@ -59,13 +66,26 @@ func (i Item) CleanTitle() string {
 	return strings.TrimSpace(i.Title)
 }
 
+// Keys returns the identifiers an item is known by: its GUID and its link,
+// prefixed with "guid:" and "link:".
+func (i Item) Keys() []string {
+	var keys []string
+	if guid := strings.TrimSpace(i.GUID); guid != "" {
+		keys = append(keys, "guid:"+guid)
+	}
+	if link := strings.TrimSpace(i.Link); link != "" {
+		keys = append(keys, "link:"+link)
+	}
+	return keys
+}
+
 func (i Item) CleanDescription() string {
 	return strings.TrimSpace(i.Description)
 }
 
 func (i Item) FormattedDate() string {
 	if t, ok := parsePubDate(i.PubDate); ok {
-		return formatOrdinalDate(t)
+		return article.FormatDateLine(t)
 	}
 	return strings.TrimSpace(i.PubDate)
 }
@@ -77,6 +97,22 @@ func (i Item) CompactDate() string {
 	return strings.TrimSpace(i.PubDate)
 }
 
+// Published returns the parsed publication date, or the zero time if unknown.
+func (i Item) Published() time.Time {
+	t, _ := parsePubDate(i.PubDate)
+	return t
+}
+
+// SearchDocument returns the searchable fields of the item.
+func (i Item) SearchDocument(section string) search.Document {
+	return search.Document{
+		Title:       i.CleanTitle(),
+		Description: i.CleanDescription(),
+		Section:     section,
+		Date:        i.Published(),
+	}
+}
+
 func parsePubDate(pubDate string) (time.Time, bool) {
 	formats := []string{
 		time.RFC1123Z,
@@ -90,42 +126,27 @@ func parsePubDate(pubDate string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func formatOrdinalDate(t time.Time) string {
-	day := t.Day()
-	suffix := ordinalSuffix(day)
-	return fmt.Sprintf("%s %d%s %d", t.Format("Jan"), day, suffix, t.Year())
-}
-
-func ordinalSuffix(day int) string {
-	if day%100 >= 11 && day%100 <= 13 {
-		return "th"
-	}
-	switch day % 10 {
-	case 1:
-		return "st"
-	case 2:
-		return "nd"
-	case 3:
-		return "rd"
-	default:
-		return "th"
-	}
-}
-
+// FetchSection returns the feed for section, from the cache while it is
+// fresh. Once it is stale the request carries the cached ETag and
+// Last-Modified, and a 304 Not Modified refreshes the cached copy.
 func FetchSection(section string) (*RSS, error) {
 	sectionPath := resolveSection(section)
-	url := fmt.Sprintf("https://www.economist.com/%s/rss.xml", sectionPath)
+	url := fmt.Sprintf("%s/%s/rss.xml", baseURL, sectionPath)
 
-	cachedBody, cachedAt, cachedOK, _ := loadCachedSection(sectionPath)
-	if cachedOK && time.Since(cachedAt) <= rssCacheTTL {
-		if rss, err := parseRSS(cachedBody); err == nil {
-			return rss, nil
+	cached, cachedFeed, cachedOK := loadCachedSection(sectionPath)
+	if offline.Enabled() {
+		if !cachedOK {
+			return nil, fmt.Errorf("%s is not cached - unavailable offline", sectionPath)
 		}
+		return cachedFeed, nil
+	}
+	if cachedOK && time.Since(cached.CachedAt) <= cache.CurrentSettings().RSSTTL {
+		return cachedFeed, nil
 	}
 
 	returnCached := func(err error) (*RSS, error) {
 		if cachedOK {
-			return parseRSS(cachedBody)
+			return cachedFeed, nil
 		}
 		return nil, err
 	}
@@ -135,14 +156,36 @@ func FetchSection(section string) (*RSS, error) {
 		return returnCached(err)
 	}
 	req.Header.Set("User-Agent", browser.UserAgent)
+	if cachedOK {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
 
 	client := &http.Client{Timeout: httpTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
+		offline.NoteFailure(err)
 		return returnCached(err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && cachedOK {
+		refreshed := *cached
+		refreshed.CachedAt = time.Time{}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			refreshed.ETag = etag
+		}
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			refreshed.LastModified = lastModified
+		}
+		_ = cache.SaveFeed(refreshed)
+		return cachedFeed, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		err := fmt.Errorf("HTTP %d from %s", resp.StatusCode, url)
 		return returnCached(err)
@@ -157,7 +200,7 @@ func FetchSection(section string) (*RSS, error) {
 	if err != nil {
 		return returnCached(err)
 	}
-	_ = saveCachedSection(sectionPath, body)
+	_ = saveCachedSection(sectionPath, body, resp.Header)
 
 	return rss, nil
 }
@@ -170,21 +213,53 @@ func parseRSS(body []byte) (*RSS, error) {
 	return &rss, nil
 }
 
+// Search returns items in a section matching query, best match first.
 func Search(section, query string) ([]Item, error) {
 	rss, err := FetchSection(section)
 	if err != nil {
 		return nil, err
 	}
+	return Rank(rss.Channel.Items, resolveSection(section), query), nil
+}
 
-	var results []Item
+// Rank filters items by query and orders them by score, keeping feed order
+// for equal scores.
+func Rank(items []Item, section, query string) []Item {
+	sections := make([]string, len(items))
+	for i := range sections {
+		sections[i] = section
+	}
 
-	for _, item := range rss.Channel.Items {
-		if matchesQuery(item, query) {
-			results = append(results, item)
+	ranked := make([]Item, 0, len(items))
+	for _, idx := range rankIndices(items, sections, query) {
+		ranked = append(ranked, items[idx])
+	}
+	return ranked
+}
+
+// rankIndices returns the indices of matching items, best match first.
+func rankIndices(items []Item, sections []string, query string) []int {
+	q := search.Parse(query)
+	type scored struct {
+		index int
+		score float64
+	}
+
+	var matches []scored
+	for i, item := range items {
+		if score, ok := q.Score(item.SearchDocument(sections[i])); ok {
+			matches = append(matches, scored{index: i, score: score})
 		}
 	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
 
-	return results, nil
+	indices := make([]int, 0, len(matches))
+	for _, match := range matches {
+		indices = append(indices, match.index)
+	}
+	return indices
 }
 
 func resolveSection(section string) string {
@@ -193,8 +268,3 @@ func resolveSection(section string) string {
 	}
 	return section
 }
-
-func matchesQuery(item Item, query string) bool {
-	text := item.CleanTitle() + " " + item.CleanDescription()
-	return search.Match(text, query)
-}
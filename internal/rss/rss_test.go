@@ -0,0 +1,23 @@
+package rss
+
+import "testing"
+
+func TestRankOrdersByScore(t *testing.T) {
+	items := []Item{
+		{Title: "Markets wobble", Description: "Investors fret about China", Link: "a"},
+		{Title: "China's exports boom", Description: "Factories hum", Link: "b"},
+		{Title: "Europe's gas", Description: "Storage is full", Link: "c"},
+	}
+
+	ranked := Rank(items, "finance-and-economics", "china")
+	if len(ranked) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(ranked))
+	}
+	if ranked[0].Link != "b" {
+		t.Fatalf("expected title match first, got %q", ranked[0].Link)
+	}
+
+	if got := Rank(items, "finance-and-economics", "section:business"); len(got) != 0 {
+		t.Fatalf("expected section filter to exclude items, got %d", len(got))
+	}
+}
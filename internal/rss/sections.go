@@ -45,3 +45,9 @@ func shortestString(strs []string) string {
 	}
 	return shortest
 }
+
+// SectionPath returns the feed path for a section alias such as "finance",
+// or section itself if it is not an alias.
+func SectionPath(section string) string {
+	return resolveSection(section)
+}
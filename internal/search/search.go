@@ -1,35 +1,319 @@
 package search
 
-import "strings"
+import (
+	"strings"
+	"time"
+	"unicode"
+)
 
-func Match(text, query string) bool {
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return true
+const (
+	titleWeight       = 3.0
+	descriptionWeight = 1.0
+	sectionWeight     = 0.5
+	prefixQuality     = 0.6
+	phraseBonus       = 1.5
+	minPrefixLen      = 4
+	dateLayout        = "2006-01-02"
+)
+
+// Document is the searchable view of a headline.
+type Document struct {
+	Title       string
+	Description string
+	Section     string
+	Date        time.Time
+}
+
+// Query is a parsed search expression.
+//
+// Supported syntax:
+//
+//	china trade          both terms (word or word-prefix match)
+//	"rate cut"           exact phrase
+//	-tariffs             exclude matches
+//	china OR india       either term
+//	title:fed            field filters: title:, desc:, section:
+//	after:2026-01-01     published on or after a date (also before:)
+type Query struct {
+	groups     [][]atom
+	exclusions []atom
+	after      time.Time
+	before     time.Time
+}
+
+type atom struct {
+	field  string
+	words  []string
+	quoted bool
+}
+
+type token struct {
+	text   string
+	field  string
+	quoted bool
+	negate bool
+}
+
+// Parse parses a query string. Parsing never fails; malformed operators are
+// treated as plain text.
+func Parse(input string) Query {
+	var q Query
+	tokens := lex(input)
+	pendingOr := false
+	for i, tok := range tokens {
+		if !tok.quoted && !tok.negate && tok.field == "" && tok.text == "OR" {
+			if len(q.groups) > 0 && i < len(tokens)-1 {
+				pendingOr = true
+			}
+			continue
+		}
+
+		switch tok.field {
+		case "after", "before":
+			if !tok.negate {
+				if t, err := time.Parse(dateLayout, tok.text); err == nil {
+					if tok.field == "after" {
+						q.after = t
+					} else {
+						q.before = t
+					}
+					pendingOr = false
+					continue
+				}
+			}
+			tok.text = tok.field + ":" + tok.text
+			tok.field = ""
+		}
+
+		a := atom{field: tok.field, words: words(tok.text), quoted: tok.quoted}
+		if len(a.words) == 0 {
+			continue
+		}
+		if tok.negate {
+			q.exclusions = append(q.exclusions, a)
+			pendingOr = false
+			continue
+		}
+		if pendingOr {
+			last := len(q.groups) - 1
+			q.groups[last] = append(q.groups[last], a)
+			pendingOr = false
+			continue
+		}
+		q.groups = append(q.groups, []atom{a})
 	}
+	return q
+}
+
+// Empty reports whether the query has no terms or filters.
+func (q Query) Empty() bool {
+	return len(q.groups) == 0 && len(q.exclusions) == 0 && q.after.IsZero() && q.before.IsZero()
+}
 
-	text = strings.ToLower(text)
-	tokens := strings.Fields(strings.ToLower(query))
+// Branches expands the positive, unfielded terms into one term list per
+// combination of OR alternatives, suitable for full-text lookups that match
+// all of a list. A quoted phrase is one term of space-separated words. Groups
+// with only fielded terms are left out.
+func (q Query) Branches() [][]string {
+	branches := [][]string{nil}
+	for _, group := range q.groups {
+		var alts [][]string
+		for _, a := range group {
+			if a.field == "" {
+				alts = append(alts, a.terms())
+			}
+		}
+		if len(alts) == 0 {
+			continue
+		}
+		next := make([][]string, 0, len(branches)*len(alts))
+		for _, branch := range branches {
+			for _, alt := range alts {
+				next = append(next, append(append([]string(nil), branch...), alt...))
+			}
+		}
+		branches = next
+	}
+	if len(branches[0]) == 0 {
+		return nil
+	}
+	return branches
+}
 
-	for _, token := range tokens {
-		if !fuzzyContains(text, token) {
+// Exclusions returns the terms of every negated, unfielded term, in the form
+// Branches uses.
+func (q Query) Exclusions() [][]string {
+	var exclusions [][]string
+	for _, a := range q.exclusions {
+		if a.field == "" {
+			exclusions = append(exclusions, a.terms())
+		}
+	}
+	return exclusions
+}
+
+// terms returns the atom's words, or a quoted phrase as a single term.
+func (a atom) terms() []string {
+	if a.quoted && len(a.words) > 1 {
+		return []string{strings.Join(a.words, " ")}
+	}
+	return a.words
+}
+
+// Filter reports whether doc passes the query's exclusions and date filters,
+// ignoring its terms.
+func (q Query) Filter(doc Document) bool {
+	if !q.after.IsZero() && (doc.Date.IsZero() || doc.Date.Before(q.after)) {
+		return false
+	}
+	if !q.before.IsZero() && (doc.Date.IsZero() || !doc.Date.Before(q.before)) {
+		return false
+	}
+	for _, a := range q.exclusions {
+		if a.score(doc) > 0 {
 			return false
 		}
 	}
 	return true
 }
 
-// fuzzyContains checks if all characters in query appear in text in order.
-func fuzzyContains(text, query string) bool {
-	if query == "" {
-		return true
+// Score reports whether doc matches the query and how well. Higher is better.
+func (q Query) Score(doc Document) (float64, bool) {
+	if !q.Filter(doc) {
+		return 0, false
+	}
+	total := 0.0
+	for _, group := range q.groups {
+		best := 0.0
+		for _, a := range group {
+			if score := a.score(doc); score > best {
+				best = score
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
 	}
+	return total, true
+}
 
-	queryIdx := 0
-	for i := 0; i < len(text) && queryIdx < len(query); i++ {
-		if text[i] == query[queryIdx] {
-			queryIdx++
+func (a atom) score(doc Document) float64 {
+	bonus := 1.0
+	if len(a.words) > 1 {
+		bonus = phraseBonus
+	}
+	switch a.field {
+	case "title":
+		return titleWeight * bonus * matchWords(words(doc.Title), a.words)
+	case "desc", "description":
+		return descriptionWeight * bonus * matchWords(words(doc.Description), a.words)
+	case "section":
+		return sectionWeight * matchSection(doc.Section, a.words)
+	}
+	score := titleWeight * matchWords(words(doc.Title), a.words)
+	score += descriptionWeight * matchWords(words(doc.Description), a.words)
+	return bonus * score
+}
+
+// matchWords returns 1 for an exact word sequence match, prefixQuality when
+// the last word only matches as a prefix, and 0 otherwise.
+func matchWords(text, query []string) float64 {
+	best := 0.0
+	for start := 0; start+len(query) <= len(text); start++ {
+		quality := 1.0
+		for i, word := range query {
+			candidate := text[start+i]
+			if candidate == word {
+				continue
+			}
+			if i == len(query)-1 && len(word) >= minPrefixLen && strings.HasPrefix(candidate, word) {
+				quality = prefixQuality
+				continue
+			}
+			quality = 0
+			break
+		}
+		if quality > best {
+			best = quality
 		}
+		if best == 1 {
+			break
+		}
+	}
+	return best
+}
+
+func matchSection(section string, query []string) float64 {
+	normalized := strings.Join(words(section), " ")
+	if normalized == "" {
+		return 0
+	}
+	if strings.Contains(normalized, strings.Join(query, " ")) {
+		return 1
+	}
+	return 0
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func lex(input string) []token {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var tok token
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			tok.negate = true
+			i++
+		}
+
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' && runes[i] != ':' {
+			i++
+		}
+		if i < len(runes) && runes[i] == ':' && i > start && isField(string(runes[start:i])) {
+			tok.field = strings.ToLower(string(runes[start:i]))
+			i++
+			start = i
+		} else {
+			i = start
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			i++
+			start = i
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			tok.text = string(runes[start:i])
+			tok.quoted = true
+			if i < len(runes) {
+				i++
+			}
+		} else {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			tok.text = string(runes[start:i])
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+func isField(name string) bool {
+	switch strings.ToLower(name) {
+	case "title", "desc", "description", "section", "after", "before":
+		return true
 	}
-	return queryIdx == len(query)
+	return false
 }
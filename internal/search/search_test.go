@@ -0,0 +1,90 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScoreTermsRequireWordMatch(t *testing.T) {
+	q := Parse("AI")
+	if _, ok := q.Score(Document{Title: "Aid to Africa is rising"}); ok {
+		t.Fatalf("expected short term not to match inside words")
+	}
+	if _, ok := q.Score(Document{Title: "The AI boom"}); !ok {
+		t.Fatalf("expected exact word match")
+	}
+	if _, ok := Parse("chin").Score(Document{Title: "Chinese exports"}); !ok {
+		t.Fatalf("expected prefix match for longer terms")
+	}
+}
+
+func TestScorePhraseExclusionAndOr(t *testing.T) {
+	doc := Document{Title: "Why the rate cut matters", Description: "Central banks and tariffs"}
+
+	if _, ok := Parse(`"rate cut"`).Score(doc); !ok {
+		t.Fatalf("expected phrase match")
+	}
+	if _, ok := Parse(`"cut rate"`).Score(doc); ok {
+		t.Fatalf("expected phrase order to matter")
+	}
+	if _, ok := Parse("rate -tariffs").Score(doc); ok {
+		t.Fatalf("expected exclusion to reject match")
+	}
+	if _, ok := Parse("india OR banks").Score(doc); !ok {
+		t.Fatalf("expected OR alternative to match")
+	}
+	if _, ok := Parse("india OR brazil").Score(doc); ok {
+		t.Fatalf("expected OR with no matching alternative to fail")
+	}
+}
+
+func TestScoreFields(t *testing.T) {
+	doc := Document{
+		Title:       "Trade wars",
+		Description: "China retaliates",
+		Section:     "finance-and-economics",
+		Date:        time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := map[string]bool{
+		"title:trade":         true,
+		"title:china":         false,
+		"desc:china":          true,
+		"section:finance":     true,
+		"section:business":    false,
+		"after:2026-01-01":    true,
+		"after:2026-03-01":    false,
+		"before:2026-03-01":   true,
+		`title:"trade wars"`:  true,
+		"trade after:garbage": false,
+	}
+	for query, want := range cases {
+		if _, got := Parse(query).Score(doc); got != want {
+			t.Errorf("query %q: expected match=%t", query, want)
+		}
+	}
+}
+
+func TestScoreRanksTitleAboveDescription(t *testing.T) {
+	q := Parse("inflation")
+	titleScore, _ := q.Score(Document{Title: "Inflation returns"})
+	descScore, _ := q.Score(Document{Title: "Prices", Description: "Inflation returns"})
+	if titleScore <= descScore {
+		t.Fatalf("expected title match (%f) to outrank description match (%f)", titleScore, descScore)
+	}
+}
+
+func TestBranches(t *testing.T) {
+	q := Parse(`china OR india "rate cut" -tariffs -title:fed title:fed after:2026-01-01`)
+	want := [][]string{{"china", "rate cut"}, {"india", "rate cut"}}
+	if got := q.Branches(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected branches %v, got %v", want, got)
+	}
+	if got := q.Exclusions(); !reflect.DeepEqual(got, [][]string{{"tariffs"}}) {
+		t.Fatalf("expected unfielded exclusions, got %v", got)
+	}
+	if got := Parse("title:fed").Branches(); got != nil {
+		t.Fatalf("expected no branches for fielded terms, got %v", got)
+	}
+}
@@ -37,11 +37,34 @@ func RenderArticleHeaderWithLayout(art *article.Article, styles ArticleStyles, l
 }
 
 func ArticleBodyMarkdown(art *article.Article) string {
-	var sb strings.Builder
-	if art.Content != "" {
-		sb.WriteString(art.Content)
+	return art.TerminalMarkdown()
+}
+
+// RenderArticleBody renders the article body ready for reflowing: styled
+// Markdown, or plain text with NoColor or PlainBody. Links become OSC 8
+// hyperlinks when opts.Hyperlinks is set and numbered footnotes otherwise.
+func RenderArticleBody(art *article.Article, opts ArticleRenderOptions) (string, error) {
+	links := art.Links()
+	base, err := RenderArticleBodyBase(articleBody(art, links, opts), opts)
+	if err != nil {
+		return "", err
 	}
-	return sb.String()
+	base = applyLinks(base, links, opts.Hyperlinks)
+	if len(links) > 0 && !opts.Hyperlinks {
+		base = strings.TrimRight(base, "\n") + "\n\n" + linkFootnotes(links)
+	}
+	return base, nil
+}
+
+// articleBody returns the body to pass to RenderArticleBodyBase: Markdown
+// when it will be styled, plain text when it is shown as is. Link text is
+// marked for applyLinks and images for drawImages.
+func articleBody(art *article.Article, links []article.Link, opts ArticleRenderOptions) string {
+	marked := markLinks(markImages(art, links, opts), links)
+	if opts.NoColor || opts.PlainBody {
+		return article.PlainText(marked.BodyBlocks())
+	}
+	return ArticleBodyMarkdown(marked)
 }
 
 func ArticleFooter(art *article.Article, styles ArticleStyles, opts ArticleRenderOptions) string {
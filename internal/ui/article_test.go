@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	cansi "github.com/charmbracelet/x/ansi"
 	"github.com/tmustier/economist-tui/internal/article"
 )
 
@@ -48,3 +49,114 @@ func TestArticleFooterFormatting(t *testing.T) {
 		t.Fatalf("expected styled URL, got %q", footer)
 	}
 }
+
+func TestArticleBodyUsesBlocks(t *testing.T) {
+	art := &article.Article{
+		Content: "Intro paragraph.\n\nSubhead\n\n• First\n• Second",
+		Blocks: []article.Block{
+			{Kind: article.BlockParagraph, Spans: []article.Span{{Text: "Intro "}, {Text: "paragraph", Bold: true}, {Text: "."}}},
+			{Kind: article.BlockHeading, Level: 2, Spans: []article.Span{{Text: "Subhead"}}},
+			{Kind: article.BlockList, Items: [][]article.Span{{{Text: "First"}}, {{Text: "Second"}}}},
+		},
+	}
+
+	plain := articleBody(art, nil, ArticleRenderOptions{NoColor: true})
+	if plain != "Intro paragraph.\n\nSubhead\n\n• First\n• Second" {
+		t.Fatalf("expected plain text body, got %q", plain)
+	}
+
+	styled := articleBody(art, nil, ArticleRenderOptions{})
+	if !strings.Contains(styled, "Intro **paragraph**.") || !strings.Contains(styled, "## Subhead") || !strings.Contains(styled, "- First") {
+		t.Fatalf("expected markdown body, got %q", styled)
+	}
+
+	base, err := RenderArticleBodyBase(styled, ArticleRenderOptions{})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if strings.Contains(base, "## ") || strings.Contains(base, "**") {
+		t.Fatalf("expected rendered markdown without markers, got %q", base)
+	}
+}
+
+func TestRenderArticleBodyLinks(t *testing.T) {
+	art := &article.Article{
+		Blocks: []article.Block{
+			{Kind: article.BlockParagraph, Spans: []article.Span{
+				{Text: "See "},
+				{Text: "earlier", Link: "https://www.economist.com/leaders/2026/01/01/a"},
+				{Text: " coverage", Bold: true, Link: "https://www.economist.com/leaders/2026/01/01/a"},
+				{Text: " and "},
+				{Text: "this", Link: "https://example.com/b"},
+				{Text: "."},
+			}},
+		},
+	}
+
+	footnotes, err := RenderArticleBody(art, ArticleRenderOptions{NoColor: true})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := "See earlier coverage[1] and this[2].\n\n[1] https://www.economist.com/leaders/2026/01/01/a\n[2] https://example.com/b"
+	if footnotes != want {
+		t.Fatalf("expected footnotes\n%q, got\n%q", want, footnotes)
+	}
+
+	linked, err := RenderArticleBody(art, ArticleRenderOptions{NoColor: true, Hyperlinks: true})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want = "See " + Hyperlink("https://www.economist.com/leaders/2026/01/01/a") + "earlier coverage" + Hyperlink("") +
+		" and " + Hyperlink("https://example.com/b") + "this" + Hyperlink("") + "."
+	if linked != want {
+		t.Fatalf("expected hyperlinks\n%q, got\n%q", want, linked)
+	}
+
+	styled, err := RenderArticleBody(art, ArticleRenderOptions{})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if strings.ContainsAny(styled, linkOpen+linkClose+linkEnd) || !strings.Contains(styled, "[2]") {
+		t.Fatalf("expected sentinels replaced with footnotes, got %q", styled)
+	}
+}
+
+func TestRenderArticleBodyFigures(t *testing.T) {
+	art := &article.Article{
+		Blocks: []article.Block{
+			{Kind: article.BlockParagraph, Spans: []article.Span{{Text: "Trade has slowed."}}},
+			{Kind: article.BlockFigure, Src: "https://example.com/chart.png", Spans: []article.Span{{Text: "Growth"}}, Credit: "Chart: The Economist"},
+			{Kind: article.BlockFigure, Alt: "A port"},
+		},
+	}
+
+	plain, err := RenderArticleBody(art, ArticleRenderOptions{NoColor: true})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := "Trade has slowed.\n\n[Chart: Growth][1]\n\n[Image: A port]\n\n[1] https://example.com/chart.png"
+	if plain != want {
+		t.Fatalf("expected placeholders\n%q, got\n%q", want, plain)
+	}
+
+	styled, err := RenderArticleBody(art, ArticleRenderOptions{Hyperlinks: true})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(styled, Hyperlink("https://example.com/chart.png")) || !strings.Contains(cansi.Strip(styled), "[Chart: Growth]") {
+		t.Fatalf("expected linked chart placeholder, got %q", styled)
+	}
+}
+
+func TestSupportsHyperlinksOverride(t *testing.T) {
+	t.Setenv("ECONOMIST_HYPERLINKS", "0")
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	if SupportsHyperlinks() {
+		t.Fatalf("expected override to disable hyperlinks")
+	}
+	t.Setenv("ECONOMIST_HYPERLINKS", "1")
+	t.Setenv("TERM_PROGRAM", "")
+	if !SupportsHyperlinks() {
+		t.Fatalf("expected override to enable hyperlinks")
+	}
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+const (
+	// cellAspect is the assumed height of a terminal cell over its width.
+	cellAspect = 2
+	// sixelCellWidth is the assumed width of a cell in pixels. Sixel images
+	// are sized in pixels rather than cells.
+	sixelCellWidth = 10
+	kittyChunkSize = 4096
+	// maxImagePixels bounds the images decoded for drawing, so a huge or
+	// hostile file cannot exhaust memory.
+	maxImagePixels = 16 << 20
+	// maxImageRows bounds how tall an image is drawn; taller images are
+	// narrowed to keep their shape.
+	maxImageRows = 40
+)
+
+// An image sentinel stands in for a figure's image while the body is
+// rendered and reflowed, numbered like the figure's link. drawImages then
+// replaces its line with the image.
+const imageMark = "\uE003"
+
+var imagePattern = regexp.MustCompile(imageMark + `(\d+)` + linkEnd)
+
+// markImages returns a copy of art with an image sentinel before each figure
+// that can be drawn with opts.
+func markImages(art *article.Article, links []article.Link, opts ArticleRenderOptions) *article.Article {
+	if opts.ImageProtocol == ImageNone || len(opts.Images) == 0 {
+		return art
+	}
+	numbers := make(map[string]int, len(links))
+	for i, link := range links {
+		numbers[link.URL] = i + 1
+	}
+
+	marked := *art
+	marked.Blocks = make([]article.Block, 0, len(art.Blocks))
+	for _, block := range art.Blocks {
+		if block.Kind == article.BlockFigure && numbers[block.Src] > 0 && drawable(opts.Images[block.Src]) {
+			text := fmt.Sprintf("%s%d%s", imageMark, numbers[block.Src], linkEnd)
+			marked.Blocks = append(marked.Blocks, article.Block{Kind: article.BlockParagraph, Spans: []article.Span{{Text: text}}})
+		}
+		marked.Blocks = append(marked.Blocks, block)
+	}
+	return &marked
+}
+
+func drawable(img article.Image) bool {
+	if len(img.Data) == 0 {
+		return false
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(img.Data))
+	return err == nil && cfg.Width > 0 && cfg.Height > 0 && cfg.Width*cfg.Height <= maxImagePixels
+}
+
+// drawImages replaces the image sentinel lines of a reflowed body with the
+// images, as wide as the content column.
+func drawImages(body string, links []article.Link, opts ArticleRenderOptions, layout ArticleLayout) string {
+	if !strings.Contains(body, imageMark) {
+		return body
+	}
+	cols := layout.ContentWidth - layout.Indent
+	indent := strings.Repeat(" ", layout.Indent+layout.OuterPadding)
+
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		match := imagePattern.FindStringSubmatch(line)
+		if match == nil {
+			out = append(out, line)
+			continue
+		}
+		n, _ := strconv.Atoi(match[1])
+		if n < 1 || n > len(links) {
+			continue
+		}
+		drawn, err := imageLines(opts.Images[links[n-1].URL].Data, opts.ImageProtocol, cols)
+		if err != nil {
+			continue
+		}
+		out = append(out, indent+drawn[0])
+		out = append(out, drawn[1:]...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// imageLines returns the lines that draw data cols cells wide, or narrower if
+// it would be more than maxImageRows tall. The first holds the escape
+// sequence; kitty images, which leave the cursor in place, are followed by
+// blank lines for the rows they cover.
+func imageLines(data []byte, protocol ImageProtocol, cols int) ([]string, error) {
+	if cols < 1 {
+		return nil, fmt.Errorf("no room for image")
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	rows := (cols*bounds.Dy() + cellAspect*bounds.Dx() - 1) / (cellAspect * bounds.Dx())
+	if rows < 1 {
+		rows = 1
+	}
+	if rows > maxImageRows {
+		rows = maxImageRows
+		cols = max(1, rows*cellAspect*bounds.Dx()/bounds.Dy())
+	}
+
+	switch protocol {
+	case ImageKitty:
+		if format != "png" {
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, img); err != nil {
+				return nil, err
+			}
+			data = buf.Bytes()
+		}
+		return append([]string{kittyImage(data, cols, rows)}, make([]string, rows-1)...), nil
+	case ImageITerm:
+		return []string{fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+			len(data), cols, rows, base64.StdEncoding.EncodeToString(data))}, nil
+	case ImageSixel:
+		return []string{sixelImage(img, cols*sixelCellWidth)}, nil
+	}
+	return nil, fmt.Errorf("unsupported image protocol %q", protocol)
+}
+
+// kittyImage transmits a PNG in chunks and places it over cols by rows cells
+// without moving the cursor.
+func kittyImage(data []byte, cols, rows int) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for i := 0; i < len(encoded); i += kittyChunkSize {
+		end := i + kittyChunkSize
+		more := 1
+		if end >= len(encoded) {
+			end, more = len(encoded), 0
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, encoded[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, encoded[i:end])
+		}
+	}
+	return b.String()
+}
+
+// sixelImage scales img to width pixels over a white background, dithers it
+// to the web-safe palette and encodes it as sixels.
+func sixelImage(img image.Image, width int) string {
+	bounds := img.Bounds()
+	height := width * bounds.Dy() / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, a := img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height).RGBA()
+			white := 0xffff - a
+			scaled.SetRGBA(x, y, color.RGBA{uint8((r + white) >> 8), uint8((g + white) >> 8), uint8((b + white) >> 8), 0xff})
+		}
+	}
+	paletted := image.NewPaletted(scaled.Bounds(), palette.WebSafe)
+	draw.FloydSteinberg.Draw(paletted, scaled.Bounds(), scaled, image.Point{})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for i, c := range paletted.Palette {
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+	for top := 0; top < height; top += 6 {
+		used := make([]bool, len(paletted.Palette))
+		for y := top; y < top+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				used[paletted.ColorIndexAt(x, y)] = true
+			}
+		}
+		for index, ok := range used {
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "#%d", index)
+			var run byte
+			count := 0
+			for x := 0; x < width; x++ {
+				var bits byte
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					if int(paletted.ColorIndexAt(x, top+dy)) == index {
+						bits |= 1 << dy
+					}
+				}
+				if ch := '?' + bits; ch == run {
+					count++
+				} else {
+					writeSixelRun(&b, run, count)
+					run, count = ch, 1
+				}
+			}
+			writeSixelRun(&b, run, count)
+			b.WriteByte('$')
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+func writeSixelRun(b *strings.Builder, ch byte, count int) {
+	switch {
+	case count == 0:
+	case count > 3:
+		fmt.Fprintf(b, "!%d%c", count, ch)
+	default:
+		b.WriteString(strings.Repeat(string(ch), count))
+	}
+}
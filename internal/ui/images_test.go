@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestRenderArticleDrawsImages(t *testing.T) {
+	chart := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		chart.Set(x, 10, color.RGBA{0xe3, 0x12, 0x0b, 0xff})
+	}
+	var data bytes.Buffer
+	if err := png.Encode(&data, chart); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(data.Bytes())
+	}))
+	defer server.Close()
+
+	art := &article.Article{
+		Title: "Graphic detail",
+		Blocks: []article.Block{
+			{Kind: article.BlockParagraph, Spans: []article.Span{{Text: "Trade has slowed."}}},
+			{Kind: article.BlockFigure, Src: server.URL + "/chart.png", Spans: []article.Span{{Text: "Growth"}}, Credit: "Chart: The Economist"},
+		},
+	}
+	images, errs := article.FetchImages(server.Client(), art)
+	if len(errs) > 0 {
+		t.Fatalf("fetch images: %v", errs)
+	}
+
+	// 62 columns leave a 60-cell content column after the body indent; a
+	// 2:1 image that wide covers 15 rows.
+	for protocol, want := range map[ImageProtocol]string{
+		ImageKitty: "\x1b_Ga=T,f=100,q=2,C=1,c=60,r=15,",
+		ImageITerm: "\x1b]1337;File=inline=1;size=" + strconv.Itoa(data.Len()) + ";width=60;height=15;",
+		ImageSixel: "\x1bP0;1;0q\"1;1;600;300#0;2;0;0;0",
+	} {
+		out, err := RenderArticle(art, ArticleRenderOptions{NoColor: true, WrapWidth: 62, TermWidth: 62, ImageProtocol: protocol, Images: images})
+		if err != nil {
+			t.Fatalf("%s: render: %v", protocol, err)
+		}
+		if !strings.Contains(out, "  "+want) || !strings.Contains(out, "[Chart: Growth]") {
+			t.Fatalf("%s: expected image %q above placeholder in:\n%q", protocol, want, out)
+		}
+		if strings.Contains(out, imageMark) {
+			t.Fatalf("%s: expected image sentinel replaced", protocol)
+		}
+	}
+
+	columns, err := RenderArticle(art, ArticleRenderOptions{NoColor: true, TermWidth: 160, TwoColumn: true, ImageProtocol: ImageKitty, Images: images})
+	if err != nil {
+		t.Fatalf("render columns: %v", err)
+	}
+	if strings.Contains(columns, "\x1b_G") || strings.Contains(columns, imageMark) || !strings.Contains(columns, "[Chart: Growth]") {
+		t.Fatalf("expected placeholder only in columns, got %q", columns)
+	}
+}
+
+func TestDetectImageProtocol(t *testing.T) {
+	for _, name := range []string{"ECONOMIST_IMAGES", "TMUX", "TERM", "TERM_PROGRAM", "KITTY_WINDOW_ID", "LC_TERMINAL"} {
+		t.Setenv(name, "")
+	}
+	cases := []struct {
+		env  map[string]string
+		want ImageProtocol
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, ImageNone},
+		{map[string]string{"TERM": "xterm-kitty"}, ImageKitty},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, ImageITerm},
+		{map[string]string{"TERM": "foot"}, ImageSixel},
+		{map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux-1000/default,1,0"}, ImageNone},
+		{map[string]string{"TERM": "xterm-kitty", "ECONOMIST_IMAGES": "sixel"}, ImageSixel},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app", "ECONOMIST_IMAGES": "none"}, ImageNone},
+	}
+	for _, tc := range cases {
+		for name, val := range tc.env {
+			t.Setenv(name, val)
+		}
+		if got := DetectImageProtocol(); got != tc.want {
+			t.Fatalf("env %v: expected %q, got %q", tc.env, tc.want, got)
+		}
+		for name := range tc.env {
+			t.Setenv(name, "")
+		}
+	}
+}
+
+func TestImageLimits(t *testing.T) {
+	// A GIF header claiming a 65535x65535 screen: cheap to send, costly to
+	// decode.
+	huge := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+	if drawable(article.Image{Data: huge}) {
+		t.Fatalf("expected oversized image rejected")
+	}
+
+	var tall bytes.Buffer
+	if err := png.Encode(&tall, image.NewRGBA(image.Rect(0, 0, 10, 400))); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if !drawable(article.Image{Data: tall.Bytes()}) {
+		t.Fatalf("expected tall image drawable")
+	}
+	lines, err := imageLines(tall.Bytes(), ImageKitty, 60)
+	if err != nil {
+		t.Fatalf("image lines: %v", err)
+	}
+	if len(lines) != maxImageRows || !strings.Contains(lines[0], "c=2,r=40,") {
+		t.Fatalf("expected image capped at %d rows, got %d lines: %.40q", maxImageRows, len(lines), lines[0])
+	}
+	sixel, err := imageLines(tall.Bytes(), ImageSixel, 60)
+	if err != nil {
+		t.Fatalf("sixel lines: %v", err)
+	}
+	if !strings.Contains(sixel[0], "\"1;1;20;800") {
+		t.Fatalf("expected sixel height bounded, got %.40q", sixel[0])
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// Link text is wrapped in private-use sentinels carrying the link number
+// while the body is rendered, so that Markdown rendering cannot mangle the
+// URL. applyLinks then swaps them for OSC 8 sequences or footnote markers.
+const (
+	linkOpen  = "\uE000"
+	linkClose = "\uE001"
+	linkEnd   = "\uE002"
+)
+
+var (
+	linkOpenPattern  = regexp.MustCompile(linkOpen + `(\d+)` + linkEnd)
+	linkClosePattern = regexp.MustCompile(linkClose + `(\d+)` + linkEnd)
+)
+
+// markLinks returns a copy of art whose link text is wrapped in sentinels
+// numbered by art.Links. Figures are replaced by their placeholder text,
+// linked to the image.
+func markLinks(art *article.Article, links []article.Link) *article.Article {
+	numbers := make(map[string]int, len(links))
+	for i, link := range links {
+		numbers[link.URL] = i + 1
+	}
+
+	marked := *art
+	marked.Blocks = make([]article.Block, len(art.Blocks))
+	for i, block := range art.Blocks {
+		if block.Kind == article.BlockFigure {
+			block = figurePlaceholder(block)
+		}
+		block.Spans = markSpans(block.Spans, numbers)
+		if len(block.Items) > 0 {
+			items := make([][]article.Span, len(block.Items))
+			for j, item := range block.Items {
+				items[j] = markSpans(item, numbers)
+			}
+			block.Items = items
+		}
+		marked.Blocks[i] = block
+	}
+	return &marked
+}
+
+// figurePlaceholder turns a figure into an italic paragraph such as
+// "[Chart: caption]" that links to the image.
+func figurePlaceholder(block article.Block) article.Block {
+	figure := block.Figure()
+	return article.Block{
+		Kind:  article.BlockParagraph,
+		Spans: []article.Span{{Text: figure.Placeholder(), Italic: true, Link: figure.Src}},
+	}
+}
+
+func markSpans(spans []article.Span, numbers map[string]int) []article.Span {
+	if len(spans) == 0 {
+		return spans
+	}
+	out := make([]article.Span, len(spans))
+	copy(out, spans)
+	for i := range out {
+		link := out[i].Link
+		if link == "" {
+			continue
+		}
+		n := numbers[link]
+		if i == 0 || out[i-1].Link != link {
+			out[i].Text = fmt.Sprintf("%s%d%s", linkOpen, n, linkEnd) + out[i].Text
+		}
+		if i == len(out)-1 || out[i+1].Link != link {
+			out[i].Text += fmt.Sprintf("%s%d%s", linkClose, n, linkEnd)
+		}
+	}
+	for i := range out {
+		out[i].Link = ""
+	}
+	return out
+}
+
+// applyLinks replaces the link sentinels in a rendered body with OSC 8
+// hyperlinks, or with footnote numbers such as "[2]".
+func applyLinks(body string, links []article.Link, hyperlinks bool) string {
+	if len(links) == 0 {
+		return body
+	}
+	url := func(match string, pattern *regexp.Regexp) (int, string) {
+		n, _ := strconv.Atoi(pattern.FindStringSubmatch(match)[1])
+		if n < 1 || n > len(links) {
+			return n, ""
+		}
+		return n, links[n-1].URL
+	}
+	body = linkOpenPattern.ReplaceAllStringFunc(body, func(match string) string {
+		_, target := url(match, linkOpenPattern)
+		if !hyperlinks || target == "" {
+			return ""
+		}
+		return Hyperlink(target)
+	})
+	return linkClosePattern.ReplaceAllStringFunc(body, func(match string) string {
+		n, target := url(match, linkClosePattern)
+		if hyperlinks && target != "" {
+			return Hyperlink("")
+		}
+		return fmt.Sprintf("[%d]", n)
+	})
+}
+
+// Hyperlink returns the OSC 8 sequence that starts a link to url, or ends
+// the current link when url is empty.
+func Hyperlink(url string) string {
+	return "\x1b]8;;" + url + "\x1b\\"
+}
+
+// linkFootnotes lists links by number, for terminals that cannot show
+// hyperlinks.
+func linkFootnotes(links []article.Link) string {
+	lines := make([]string, len(links))
+	for i, link := range links {
+		lines[i] = fmt.Sprintf("[%d] %s", i+1, link.URL)
+	}
+	return strings.Join(lines, "\n")
+}
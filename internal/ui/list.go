@@ -8,10 +8,16 @@ import (
 )
 
 // ListItem represents a row with an optional right-aligned column.
+// Badge is a pre-rendered overline (see SectionBadge) shown above the title.
+// Detail is right-aligned under Right on the row after the title's first line.
+// Read items are drawn with the Read style unless selected.
 type ListItem struct {
+	Badge    string
 	Title    string
 	Subtitle string
 	Right    string
+	Detail   string
+	Read     bool
 }
 
 // ListStyles controls how list rows are styled.
@@ -21,6 +27,7 @@ type ListStyles struct {
 	Selected      lipgloss.Style
 	Right         lipgloss.Style
 	RightSelected lipgloss.Style
+	Read          lipgloss.Style
 }
 
 // ListOptions configures list rendering.
@@ -106,6 +113,11 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 		item := items[i]
 		lineStyle := styles.Title
 		rightStyle := styles.Right
+		subtitleStyle := styles.Subtitle
+		if item.Read {
+			lineStyle = styles.Read
+			subtitleStyle = styles.Read
+		}
 		if i == opts.SelectedIndex {
 			lineStyle = styles.Selected
 			rightStyle = styles.RightSelected
@@ -116,6 +128,27 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 			prefix = opts.Prefix(i)
 		}
 
+		if item.Badge != "" {
+			b.WriteString(prefixPad + item.Badge + "\n")
+		}
+
+		// Continuation lines are unpadded; the one carrying Detail is padded
+		// so the detail lines up under Right.
+		detailPending := item.Detail != "" && layout.RightWidth > 0
+		writeLine := func(line string, style lipgloss.Style) {
+			if detailPending {
+				detailPending = false
+				b.WriteString(prefixPad + style.Render(fmt.Sprintf("%-*s", layout.TitleWidth, line)))
+				b.WriteString(rightStyle.Render(fmt.Sprintf("%*s", layout.RightWidth, item.Detail)) + "\n")
+				return
+			}
+			if line == "" {
+				b.WriteString(prefixPad + "\n")
+				return
+			}
+			b.WriteString(fmt.Sprintf("%s%s\n", prefixPad, style.Render(line)))
+		}
+
 		titleLines := LimitLines(WrapLines(item.Title, layout.TitleWidth), opts.TitleLines, layout.TitleWidth)
 		if len(titleLines) == 0 {
 			titleLines = []string{""}
@@ -132,20 +165,12 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 				b.WriteString("\n")
 				continue
 			}
-			if line == "" {
-				b.WriteString(prefixPad + "\n")
-				continue
-			}
-			b.WriteString(fmt.Sprintf("%s%s\n", prefixPad, lineStyle.Render(line)))
+			writeLine(line, lineStyle)
 		}
 
 		subtitleLines := LimitLines(WrapLines(item.Subtitle, layout.TitleWidth), opts.SubtitleLines, layout.TitleWidth)
 		for _, line := range subtitleLines {
-			if line == "" {
-				b.WriteString(prefixPad + "\n")
-				continue
-			}
-			b.WriteString(fmt.Sprintf("%s%s\n", prefixPad, styles.Subtitle.Render(line)))
+			writeLine(line, subtitleStyle)
 		}
 
 		if gapLines > 0 {
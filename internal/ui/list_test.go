@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/ansi"
 )
 
@@ -43,3 +44,63 @@ func TestRenderListAlignsRightColumn(t *testing.T) {
 		t.Fatalf("expected line width 20, got %d", width)
 	}
 }
+
+func TestRenderListBadgeAboveTitle(t *testing.T) {
+	items := []ListItem{{Badge: "FINANCE", Title: "Hello"}}
+	out := RenderList(items, ListOptions{
+		Width:       20,
+		PrefixWidth: 3,
+		TitleLines:  1,
+		Start:       0,
+		End:         1,
+	}, ListStyles{})
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected badge and title lines, got %q", lines)
+	}
+	if lines[0] != "   FINANCE" {
+		t.Fatalf("expected indented badge, got %q", lines[0])
+	}
+}
+
+func TestRenderListUsesReadStyleUnlessSelected(t *testing.T) {
+	items := []ListItem{{Title: "first", Read: true}, {Title: "second", Read: true}}
+	out := RenderList(items, ListOptions{
+		Width:         20,
+		TitleLines:    1,
+		SelectedIndex: 1,
+		Start:         0,
+		End:           2,
+	}, ListStyles{Read: lipgloss.NewStyle().Transform(strings.ToUpper)})
+
+	if !strings.Contains(out, "FIRST") {
+		t.Fatalf("expected read style on unselected item, got %q", out)
+	}
+	if !strings.Contains(out, "second") {
+		t.Fatalf("expected selected item to keep selected style, got %q", out)
+	}
+}
+
+func TestRenderListDetailOnSecondRow(t *testing.T) {
+	items := []ListItem{{Title: "Title", Subtitle: "Subtitle", Right: "Jan 1", Detail: "40%"}}
+	out := RenderList(items, ListOptions{
+		Width:            30,
+		RightColumnWidth: 6,
+		TitleLines:       1,
+		SubtitleLines:    1,
+		Start:            0,
+		End:              1,
+	}, ListStyles{})
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected title and subtitle rows, got %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], " Jan 1") || !strings.HasSuffix(lines[1], "   40%") {
+		t.Fatalf("expected right column then detail, got %q", lines)
+	}
+	if ansi.PrintableRuneWidth(lines[1]) != 30 {
+		t.Fatalf("expected detail row padded to width, got %q", lines[1])
+	}
+}
@@ -30,6 +30,12 @@ type ArticleRenderOptions struct {
 	MaxWidth  int
 	Center    bool
 	TwoColumn bool
+	// Hyperlinks shows links as OSC 8 hyperlinks instead of footnotes.
+	Hyperlinks bool
+	// ImageProtocol draws the figures whose image is in Images inline, above
+	// their placeholder. Column layouts keep the placeholder only.
+	ImageProtocol ImageProtocol
+	Images        map[string]article.Image
 }
 
 type ArticleLayout struct {
@@ -49,10 +55,11 @@ func RenderArticle(art *article.Article, opts ArticleRenderOptions) (string, err
 		return art.ToMarkdown(), nil
 	}
 
+	if ResolveArticleLayout(opts).UseColumns {
+		opts.ImageProtocol = ImageNone
+	}
 	styles := NewArticleStyles(opts.NoColor)
-	markdown := ArticleBodyMarkdown(art)
-
-	base, err := RenderArticleBodyBase(markdown, opts)
+	base, err := RenderArticleBody(art, opts)
 	if err != nil {
 		return "", err
 	}
@@ -60,6 +67,7 @@ func RenderArticle(art *article.Article, opts ArticleRenderOptions) (string, err
 	layout := ResolveArticleLayoutWithContent(base, opts)
 	header := RenderArticleHeaderWithLayout(art, styles, layout, opts)
 	body := ReflowArticleBodyWithLayout(base, styles, opts, layout)
+	body = drawImages(body, art.Links(), opts, layout)
 	indent := ArticleIndentForLayout(layout)
 	if indent > 0 {
 		header = IndentBlock(header, indent)
@@ -260,6 +268,12 @@ func RenderArticleBodyBase(markdown string, opts ArticleRenderOptions) (string,
 	}
 	styles.Document.Margin = uintPtr(0)
 	styles.Document.Color = &bodyColor
+	// Body headings are section breaks, not document titles.
+	headingColor := string(CurrentTheme().Brand)
+	styles.Heading.Color = &headingColor
+	styles.H2.Prefix = ""
+	styles.H3.Prefix = ""
+	styles.H4.Prefix = ""
 
 	optsList := []glamour.TermRendererOption{
 		glamour.WithStyles(styles),
@@ -318,6 +332,39 @@ func ReflowArticleBodyWithLayout(base string, styles ArticleStyles, opts Article
 	return body
 }
 
+// ParagraphRows returns the row of the reflowed body on which each paragraph
+// of base starts. In column layouts rows restart at the top of each column,
+// so the result is only ascending within a column.
+func ParagraphRows(base string, layout ArticleLayout) []int {
+	body := base
+	if layout.WrapWidth > 0 {
+		body = wrapBody(body, layout.WrapWidth)
+	}
+	body = normalizeParagraphSpacing(body)
+
+	lines := strings.Split(body, "\n")
+	rows := 0
+	if layout.UseColumns && layout.ColumnCount > 1 {
+		lines = trimTrailingBlankLines(trimLeadingBlankLines(strings.Split(strings.TrimRight(body, "\n"), "\n")))
+		rows = (len(lines) + layout.ColumnCount - 1) / layout.ColumnCount
+	}
+
+	var starts []int
+	prevBlank := true
+	for i, line := range lines {
+		blank := isLineBlank(line)
+		if !blank && prevBlank {
+			row := i
+			if rows > 0 {
+				row = i % rows
+			}
+			starts = append(starts, row)
+		}
+		prevBlank = blank
+	}
+	return starts
+}
+
 func columnize(text string, columnWidth int, columnCount int) string {
 	if columnCount <= 1 {
 		return text
@@ -367,7 +414,7 @@ func padRightANSI(text string, width int) string {
 		return text
 	}
 	trimmed := cansi.Truncate(text, width, "")
-	pad := width - ansi.PrintableRuneWidth(trimmed)
+	pad := width - cansi.StringWidth(trimmed)
 	if pad <= 0 {
 		return trimmed
 	}
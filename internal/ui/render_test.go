@@ -130,3 +130,31 @@ func TestHighlightTrailingMarker(t *testing.T) {
 		t.Fatalf("expected marker replacement, got %q", output)
 	}
 }
+
+func TestParagraphRowsMatchReflow(t *testing.T) {
+	styles := NewArticleStyles(true)
+	paragraphs := []string{
+		strings.Repeat("alpha ", 30),
+		strings.Repeat("bravo ", 30),
+		strings.Repeat("charlie ", 30),
+	}
+	base := strings.Join(paragraphs, "\n\n")
+
+	for _, twoColumn := range []bool{false, true} {
+		opts := ArticleRenderOptions{NoColor: true, WrapWidth: 80, TwoColumn: twoColumn}
+		layout := ResolveArticleLayoutWithContent(base, opts)
+		body := ReflowArticleBodyWithLayout(base, styles, opts, layout)
+		lines := strings.Split(body, "\n")
+
+		rows := ParagraphRows(base, layout)
+		if len(rows) != len(paragraphs) {
+			t.Fatalf("columns=%t: expected %d paragraphs, got %v", twoColumn, len(paragraphs), rows)
+		}
+		for i, row := range rows {
+			word := strings.Fields(paragraphs[i])[0]
+			if row >= len(lines) || !strings.Contains(lines[row], word) {
+				t.Fatalf("columns=%t: expected paragraph %d on row %d, got %q", twoColumn, i, row, lines[row])
+			}
+		}
+	}
+}
@@ -14,6 +14,7 @@ type BrowseStyles struct {
 	Subtitle lipgloss.Style
 	Selected lipgloss.Style
 	Dim      lipgloss.Style
+	Read     lipgloss.Style
 	Help     lipgloss.Style
 	Search   lipgloss.Style
 
@@ -114,6 +115,7 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 	subtitle := lipgloss.NewStyle().Foreground(theme.TextMuted)
 	selected := lipgloss.NewStyle().Bold(true).Foreground(theme.Brand)
 	dim := lipgloss.NewStyle().Foreground(theme.TextFaint)
+	read := lipgloss.NewStyle().Foreground(theme.TextFaint)
 	help := lipgloss.NewStyle().Foreground(theme.TextFaint)
 	search := lipgloss.NewStyle().Foreground(theme.TextFaint)
 
@@ -131,6 +133,7 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 		subtitle = lipgloss.NewStyle()
 		selected = lipgloss.NewStyle().Bold(true)
 		dim = lipgloss.NewStyle()
+		read = lipgloss.NewStyle().Faint(true)
 		help = lipgloss.NewStyle()
 		search = lipgloss.NewStyle()
 		searchIdle = lipgloss.NewStyle()
@@ -146,6 +149,7 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 		Subtitle:      subtitle,
 		Selected:      selected,
 		Dim:           dim,
+		Read:          read,
 		Help:          help,
 		Search:        search,
 		SearchIdle:    searchIdle,
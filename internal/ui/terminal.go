@@ -3,6 +3,7 @@ package ui
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"golang.org/x/term"
 )
@@ -40,3 +41,66 @@ func TermWidth(fd int) int {
 func IsTerminal(fd int) bool {
 	return term.IsTerminal(fd)
 }
+
+// SupportsHyperlinks guesses from the environment whether the terminal shows
+// OSC 8 hyperlinks. ECONOMIST_HYPERLINKS=1 or 0 overrides the guess.
+func SupportsHyperlinks() bool {
+	if val, ok := os.LookupEnv("ECONOMIST_HYPERLINKS"); ok {
+		enabled, err := strconv.ParseBool(val)
+		return err == nil && enabled
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "rio":
+		return true
+	}
+	term := os.Getenv("TERM")
+	for _, name := range []string{"kitty", "alacritty", "foot", "wezterm", "ghostty"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageProtocol is a terminal graphics protocol for drawing images inline.
+type ImageProtocol string
+
+const (
+	ImageNone  ImageProtocol = ""
+	ImageKitty ImageProtocol = "kitty"
+	ImageITerm ImageProtocol = "iterm"
+	ImageSixel ImageProtocol = "sixel"
+)
+
+// DetectImageProtocol guesses from the environment which graphics protocol
+// the terminal supports, if any. ECONOMIST_IMAGES=kitty, iterm, sixel or none
+// overrides the guess. Multiplexers are assumed not to pass images through.
+func DetectImageProtocol() ImageProtocol {
+	if val := strings.TrimSpace(os.Getenv("ECONOMIST_IMAGES")); val != "" {
+		switch protocol := ImageProtocol(strings.ToLower(val)); protocol {
+		case ImageKitty, ImageITerm, ImageSixel:
+			return protocol
+		}
+		return ImageNone
+	}
+	term := os.Getenv("TERM")
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return ImageNone
+	}
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || program == "ghostty" || strings.Contains(term, "kitty") || strings.Contains(term, "ghostty"):
+		return ImageKitty
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ImageITerm
+	case program == "mlterm" || strings.Contains(term, "foot") || strings.Contains(term, "mlterm") || strings.Contains(term, "sixel"):
+		return ImageSixel
+	}
+	return ImageNone
+}
@@ -0,0 +1,218 @@
+// Package watch polls section feeds and reports headlines not seen before.
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/atomicfile"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+const (
+	stateFileName = "watch.json"
+
+	// forgetAfter is how long a seen key is kept once its item has left
+	// every watched feed.
+	forgetAfter = 30 * 24 * time.Hour
+)
+
+// State records which item keys have been seen and which sections have been
+// polled at least once. Keys are rss.Item.Keys, so an item is matched by GUID
+// or by link.
+type State struct {
+	Sections map[string]time.Time `json:"sections"`
+	Seen     map[string]time.Time `json:"seen"`
+}
+
+func Path() string {
+	return filepath.Join(config.ConfigDir(), stateFileName)
+}
+
+// New returns an empty in-memory state.
+func New() *State {
+	return &State{Sections: make(map[string]time.Time), Seen: make(map[string]time.Time)}
+}
+
+// Load reads the state from disk. A missing file yields an empty state.
+func Load() (*State, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(), nil
+		}
+		return nil, err
+	}
+
+	state := New()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Sections == nil {
+		state.Sections = make(map[string]time.Time)
+	}
+	if state.Seen == nil {
+		state.Seen = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state, first merging in what other watch processes saved
+// since it was loaded, so they do not report each other's items again.
+func (s *State) Save() error {
+	unlock, err := atomicfile.Lock(Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	saved, err := Load()
+	if err != nil {
+		return err
+	}
+	s.merge(saved, time.Now().UTC())
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(Path(), data)
+}
+
+// merge adds other's sections and seen keys, keeping the earliest times.
+// Keys past forgetAfter are left out so forget can drop them.
+func (s *State) merge(other *State, now time.Time) {
+	for key, first := range other.Seen {
+		if now.Sub(first) > forgetAfter {
+			continue
+		}
+		if mine, ok := s.Seen[key]; !ok || first.Before(mine) {
+			s.Seen[key] = first
+		}
+	}
+	for section, first := range other.Sections {
+		if mine, ok := s.Sections[section]; !ok || first.Before(mine) {
+			s.Sections[section] = first
+		}
+	}
+}
+
+func (s *State) seen(keys []string) bool {
+	for _, key := range keys {
+		if _, ok := s.Seen[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *State) mark(keys []string, now time.Time) {
+	for _, key := range keys {
+		if _, ok := s.Seen[key]; !ok {
+			s.Seen[key] = now
+		}
+	}
+}
+
+// Options configure a Watcher.
+type Options struct {
+	Sections []string
+	// Query filters new items with the headline search syntax. Items that do
+	// not match are still marked seen.
+	Query string
+	// IncludeExisting reports the items already in a feed the first time it
+	// is polled. Otherwise they are recorded as seen without being reported.
+	IncludeExisting bool
+	// Fetch loads one section feed. Nil uses rss.FetchSection.
+	Fetch func(section string) (*rss.RSS, error)
+}
+
+// Watcher diffs section feeds against the saved state.
+type Watcher struct {
+	opts  Options
+	state *State
+}
+
+// NewWatcher returns a watcher that records what it has seen in state.
+func NewWatcher(opts Options, state *State) *Watcher {
+	if opts.Fetch == nil {
+		opts.Fetch = rss.FetchSection
+	}
+	return &Watcher{opts: opts, state: state}
+}
+
+// Poll fetches every section and returns the items not seen before, oldest
+// first. Sections that fail are skipped; the returned error describes them.
+// The caller saves the state.
+func (w *Watcher) Poll() ([]rss.TaggedItem, error) {
+	now := time.Now().UTC()
+
+	var feeds []rss.SectionItems
+	var errs []error
+	fetched := make(map[string]bool)
+	for _, section := range w.opts.Sections {
+		feed, err := w.opts.Fetch(section)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", section, err))
+			continue
+		}
+		feeds = append(feeds, rss.SectionItems{Section: section, Items: feed.Channel.Items})
+		fetched[section] = true
+	}
+
+	var fresh []rss.TaggedItem
+	current := make(map[string]bool)
+	for _, item := range rss.Merge(feeds) {
+		keys := item.Keys()
+		for _, key := range keys {
+			current[key] = true
+		}
+		if w.state.seen(keys) {
+			continue
+		}
+		w.state.mark(keys, now)
+		if w.opts.IncludeExisting || w.polledBefore(item.Sections) {
+			fresh = append(fresh, item)
+		}
+	}
+	for section := range fetched {
+		if _, ok := w.state.Sections[rss.SectionPath(section)]; !ok {
+			w.state.Sections[rss.SectionPath(section)] = now
+		}
+	}
+	w.forget(current, now)
+
+	if w.opts.Query != "" {
+		fresh = rss.RankTagged(fresh, w.opts.Query)
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].Published().Before(fresh[j].Published())
+	})
+	return fresh, errors.Join(errs...)
+}
+
+// polledBefore reports whether any of sections has been polled before, so
+// its current items are news rather than the backlog.
+func (w *Watcher) polledBefore(sections []string) bool {
+	for _, section := range sections {
+		if _, ok := w.state.Sections[rss.SectionPath(section)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// forget drops keys for items that are no longer in any feed and were first
+// seen more than forgetAfter ago, so the state does not grow forever.
+func (w *Watcher) forget(current map[string]bool, now time.Time) {
+	for key, first := range w.state.Seen {
+		if !current[key] && now.Sub(first) > forgetAfter {
+			delete(w.state.Seen, key)
+		}
+	}
+}
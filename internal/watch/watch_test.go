@@ -0,0 +1,203 @@
+package watch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+type fakeFeeds map[string][]rss.Item
+
+func (f fakeFeeds) fetch(section string) (*rss.RSS, error) {
+	items, ok := f[section]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	feed := &rss.RSS{}
+	feed.Channel.Items = items
+	return feed, nil
+}
+
+func item(guid, title string, published time.Time) rss.Item {
+	return rss.Item{
+		Title:   title,
+		Link:    "https://www.economist.com/" + guid,
+		GUID:    guid,
+		PubDate: published.Format(time.RFC1123Z),
+	}
+}
+
+func titles(items []rss.TaggedItem) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Title)
+	}
+	return out
+}
+
+func TestPollReportsOnlyNewItems(t *testing.T) {
+	base := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	feeds := fakeFeeds{"leaders": {item("a", "A", base)}}
+	state := New()
+	w := NewWatcher(Options{Sections: []string{"leaders"}, Fetch: feeds.fetch}, state)
+
+	items, err := w.Poll()
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected first poll to only seed, got %v", titles(items))
+	}
+
+	feeds["leaders"] = []rss.Item{
+		item("c", "C", base.Add(2*time.Hour)),
+		item("b", "B", base.Add(time.Hour)),
+		item("a", "A", base),
+	}
+	items, err = w.Poll()
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if got := titles(items); len(got) != 2 || got[0] != "B" || got[1] != "C" {
+		t.Fatalf("expected new items oldest first, got %v", got)
+	}
+
+	items, _ = w.Poll()
+	if len(items) != 0 {
+		t.Fatalf("expected nothing new, got %v", titles(items))
+	}
+}
+
+func TestPollIncludeExisting(t *testing.T) {
+	feeds := fakeFeeds{"leaders": {item("a", "A", time.Now())}}
+	w := NewWatcher(Options{Sections: []string{"leaders"}, IncludeExisting: true, Fetch: feeds.fetch}, New())
+
+	items, err := w.Poll()
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected existing item to be reported, got %v", titles(items))
+	}
+}
+
+func TestPollFiltersAndDedupes(t *testing.T) {
+	now := time.Now()
+	feeds := fakeFeeds{"leaders": {}, "finance": {}}
+	w := NewWatcher(Options{Sections: []string{"leaders", "finance"}, Query: "china", Fetch: feeds.fetch}, New())
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	shared := item("a", "China's economy", now)
+	feeds["leaders"] = []rss.Item{shared, item("b", "Europe's banks", now)}
+	feeds["finance"] = []rss.Item{shared}
+	items, err := w.Poll()
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "China's economy" || len(items[0].Sections) != 2 {
+		t.Fatalf("expected one matching item from both sections, got %+v", items)
+	}
+
+	w.opts.Query = ""
+	if items, _ := w.Poll(); len(items) != 0 {
+		t.Fatalf("expected filtered item to stay seen, got %v", titles(items))
+	}
+}
+
+func TestPollSkipsFailedSections(t *testing.T) {
+	feeds := fakeFeeds{"leaders": {item("a", "A", time.Now())}}
+	state := New()
+	w := NewWatcher(Options{Sections: []string{"leaders", "missing"}, Fetch: feeds.fetch}, state)
+
+	if _, err := w.Poll(); err == nil {
+		t.Fatalf("expected error for missing section")
+	}
+	if len(state.Seen) == 0 {
+		t.Fatalf("expected working section to be recorded")
+	}
+	if _, ok := state.Sections[rss.SectionPath("missing")]; ok {
+		t.Fatalf("expected failed section not to be marked polled")
+	}
+}
+
+func TestPollForgetsOldKeys(t *testing.T) {
+	feeds := fakeFeeds{"leaders": {}}
+	state := New()
+	state.Seen["guid:gone"] = time.Now().Add(-2 * forgetAfter)
+	state.Seen["guid:recent"] = time.Now()
+	w := NewWatcher(Options{Sections: []string{"leaders"}, Fetch: feeds.fetch}, state)
+
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if _, ok := state.Seen["guid:gone"]; ok {
+		t.Fatalf("expected old key to be forgotten")
+	}
+	if _, ok := state.Seen["guid:recent"]; !ok {
+		t.Fatalf("expected recent key to be kept")
+	}
+}
+
+func TestStatePersists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	feeds := fakeFeeds{"leaders": {item("a", "A", time.Now())}}
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("load empty: %v", err)
+	}
+	if _, err := NewWatcher(Options{Sections: []string{"leaders"}, Fetch: feeds.fetch}, state).Poll(); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if err := state.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	feeds["leaders"] = append(feeds["leaders"], item("b", "B", time.Now()))
+	state, err = Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	items, err := NewWatcher(Options{Sections: []string{"leaders"}, Fetch: feeds.fetch}, state).Poll()
+	if err != nil {
+		t.Fatalf("poll after load: %v", err)
+	}
+	if got := titles(items); len(got) != 1 || got[0] != "B" {
+		t.Fatalf("expected only B after reload, got %v", got)
+	}
+}
+
+func TestSaveMergesConcurrentWatchers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+	feeds := fakeFeeds{
+		"leaders":  {item("a", "Alpha", now)},
+		"business": {item("b", "Beta", now)},
+	}
+
+	first, _ := Load()
+	second, _ := Load()
+	NewWatcher(Options{Sections: []string{"leaders"}, Fetch: feeds.fetch}, first).Poll()
+	NewWatcher(Options{Sections: []string{"business"}, Fetch: feeds.fetch}, second).Poll()
+	if err := first.Save(); err != nil {
+		t.Fatalf("save first: %v", err)
+	}
+	if err := second.Save(); err != nil {
+		t.Fatalf("save second: %v", err)
+	}
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !state.seen(feeds["leaders"][0].Keys()) || !state.seen(feeds["business"][0].Keys()) {
+		t.Fatalf("expected both watchers' items kept, got %v", state.Seen)
+	}
+	if len(state.Sections) != 2 {
+		t.Fatalf("expected both sections kept, got %v", state.Sections)
+	}
+}